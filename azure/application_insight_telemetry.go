@@ -0,0 +1,279 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/appinsights/v1/insights"
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
+)
+
+// ApplicationInsightTelemetryRow represents a single telemetry item returned by the Application Insights query API
+// https://learn.microsoft.com/en-us/azure/azure-monitor/app/data-model-complete
+type ApplicationInsightTelemetryRow struct {
+	AppID     string
+	Timestamp *time.Time
+	Row       map[string]interface{}
+}
+
+// applicationInsightTelemetryKeyColumns returns the key columns shared by all Application Insights telemetry tables.
+// filterColumns lists the table specific columns that can be pushed down into the KQL query.
+func applicationInsightTelemetryKeyColumns(filterColumns map[string]string) plugin.KeyColumnSlice {
+	keyColumns := plugin.KeyColumnSlice{
+		{
+			Name:    "app_id",
+			Require: plugin.Required,
+		},
+		{
+			Name:       "timestamp",
+			Require:    plugin.Optional,
+			Operators:  []string{">", ">=", "<", "<=", "="},
+			CacheMatch: query_cache.CacheMatchExact,
+		},
+	}
+	// Sort the column names, as the order of the key columns must not change between plugin starts
+	for _, columnName := range slices.Sorted(maps.Keys(filterColumns)) {
+		keyColumns = append(keyColumns, &plugin.KeyColumn{
+			Name:      columnName,
+			Require:   plugin.Optional,
+			Operators: []string{"=", "<>"},
+		})
+	}
+	return keyColumns
+}
+
+// applicationInsightTelemetryColumns returns the table specific columns followed by the columns common to all telemetry types
+func applicationInsightTelemetryColumns(columns []*plugin.Column) []*plugin.Column {
+	commonColumns := []*plugin.Column{
+		{
+			Name:        "app_id",
+			Description: "The ID of the Application Insights application.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("AppID"),
+		},
+		{
+			Name:        "timestamp",
+			Description: "The time the telemetry item was recorded. Defaults to the last 24 hours if not specified in the where clause.",
+			Type:        proto.ColumnType_TIMESTAMP,
+		},
+		{
+			Name:        "item_id",
+			Description: "The unique ID of the telemetry item.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Row.itemId"),
+		},
+		{
+			Name:        "operation_name",
+			Description: "The name of the operation the telemetry item belongs to.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Row.operation_Name"),
+		},
+		{
+			Name:        "operation_id",
+			Description: "The ID of the operation the telemetry item belongs to.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Row.operation_Id"),
+		},
+		{
+			Name:        "cloud_role_name",
+			Description: "The name of the role the application is part of.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Row.cloud_RoleName"),
+		},
+		{
+			Name:        "cloud_role_instance",
+			Description: "The name of the instance the application is running on.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Row.cloud_RoleInstance"),
+		},
+		{
+			Name:        "client_type",
+			Description: "The type of the client device, e.g. Browser or PC.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Row.client_Type"),
+		},
+		{
+			Name:        "app_name",
+			Description: "The name of the Application Insights component.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Row.appName"),
+		},
+		{
+			Name:        "custom_dimensions",
+			Description: "The custom properties attached to the telemetry item.",
+			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromField("Row.customDimensions").Transform(parseTelemetryDynamicValue),
+		},
+		{
+			Name:        "custom_measurements",
+			Description: "The custom measurements attached to the telemetry item.",
+			Type:        proto.ColumnType_JSON,
+			Transform:   transform.FromField("Row.customMeasurements").Transform(parseTelemetryDynamicValue),
+		},
+		{
+			Name:        "row",
+			Description: "The complete telemetry item as returned by the query API.",
+			Type:        proto.ColumnType_JSON,
+		},
+	}
+
+	return append(columns, commonColumns...)
+}
+
+// streamApplicationInsightTelemetry runs a KQL query against the given telemetry table of an application and streams the results.
+// Quals on timestamp and on the columns in filterColumns are translated into KQL where clauses.
+func streamApplicationInsightTelemetry(ctx context.Context, d *plugin.QueryData, telemetryTable string, filterColumns map[string]string) (interface{}, error) {
+	appID := d.EqualsQualString("app_id")
+	if appID == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "APPLICATION_INSIGHTS")
+	if err != nil {
+		plugin.Logger(ctx).Error("streamApplicationInsightTelemetry", "session_error", err)
+		return nil, err
+	}
+
+	client := insights.NewQueryClientWithBaseURI(session.ApplicationInsightsEndpoint + "/v1")
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	query := buildApplicationInsightTelemetryQuery(d, telemetryTable, filterColumns)
+	plugin.Logger(ctx).Debug("streamApplicationInsightTelemetry", "app_id", appID, "query", query)
+
+	result, err := client.Execute(ctx, appID, insights.QueryBody{Query: &query})
+	if err != nil {
+		plugin.Logger(ctx).Error("streamApplicationInsightTelemetry", "api_error", err)
+		return nil, err
+	}
+
+	if result.Tables == nil {
+		return nil, nil
+	}
+
+	for _, table := range *result.Tables {
+		if table.Columns == nil || table.Rows == nil {
+			continue
+		}
+		for _, values := range *table.Rows {
+			row := make(map[string]interface{}, len(*table.Columns))
+			for i, column := range *table.Columns {
+				if column.Name != nil && i < len(values) {
+					row[*column.Name] = values[i]
+				}
+			}
+
+			d.StreamListItem(ctx, ApplicationInsightTelemetryRow{
+				AppID:     appID,
+				Timestamp: parseQueryResultTime(row["timestamp"]),
+				Row:       row,
+			})
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// buildApplicationInsightTelemetryQuery builds the KQL query for a telemetry table from the query quals
+func buildApplicationInsightTelemetryQuery(d *plugin.QueryData, telemetryTable string, filterColumns map[string]string) string {
+	var conditions []string
+
+	// The query API returns the whole retention period (90 days by default) if no time range is given,
+	// so default to the last 24 hours unless a lower bound is specified.
+	hasLowerBound := false
+	if d.Quals["timestamp"] != nil {
+		for _, q := range d.Quals["timestamp"].Quals {
+			ts := q.Value.GetTimestampValue().AsTime().UTC().Format(time.RFC3339Nano)
+			switch q.Operator {
+			case "=":
+				hasLowerBound = true
+				conditions = append(conditions, fmt.Sprintf("timestamp == datetime(%s)", ts))
+			case ">", ">=":
+				hasLowerBound = true
+				conditions = append(conditions, fmt.Sprintf("timestamp %s datetime(%s)", q.Operator, ts))
+			case "<", "<=":
+				conditions = append(conditions, fmt.Sprintf("timestamp %s datetime(%s)", q.Operator, ts))
+			}
+		}
+	}
+	if !hasLowerBound {
+		conditions = append(conditions, "timestamp > ago(1d)")
+	}
+
+	for columnName, fieldName := range filterColumns {
+		if d.Quals[columnName] == nil {
+			continue
+		}
+		for _, q := range d.Quals[columnName].Quals {
+			value := q.Value.GetStringValue()
+			switch q.Operator {
+			case "=":
+				conditions = append(conditions, fmt.Sprintf("%s == %s", fieldName, kqlStringLiteral(value)))
+			case "<>":
+				conditions = append(conditions, fmt.Sprintf("%s != %s", fieldName, kqlStringLiteral(value)))
+			}
+		}
+	}
+
+	return telemetryTable + " | where " + strings.Join(conditions, " and ")
+}
+
+// kqlStringLiteral quotes a value as a KQL string literal
+func kqlStringLiteral(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+//// TRANSFORM FUNCTIONS
+
+// parseTelemetryDynamicValue converts a KQL dynamic value, which the query API returns as a JSON encoded string, into an object
+func parseTelemetryDynamicValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	str, ok := d.Value.(string)
+	if !ok || str == "" {
+		return d.Value, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(str), &value); err != nil {
+		return str, nil
+	}
+	return value, nil
+}
+
+// telemetryBoolValue converts the success flag of a telemetry item, which can be returned as "True"/"False" or "1"/"0", into a bool
+func telemetryBoolValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	switch v := d.Value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0, nil
+	default:
+		switch strings.ToLower(types.SafeString(v)) {
+		case "true", "1":
+			return true, nil
+		case "false", "0":
+			return false, nil
+		}
+	}
+	return nil, nil
+}
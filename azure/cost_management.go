@@ -11,6 +11,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement/v2"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
 )
@@ -45,12 +46,15 @@ type CostManagementRow struct {
 }
 
 // getGranularityFromString converts granularity string to Azure GranularityType
-// Azure supports both Daily and Monthly, but we need to use the right type
+// Azure supports Hourly, Daily and Monthly, but the SDK only defines Daily, so we need to use the right type
 func getGranularityFromString(granularity string) armcostmanagement.GranularityType {
 	switch granularity {
 	case "MONTHLY":
 		// Use Monthly granularity directly as string (same as raw API)
 		return armcostmanagement.GranularityType("Monthly")
+	case "HOURLY":
+		// Hourly granularity is only supported by some billing scopes, e.g. Enterprise Agreement and Microsoft Customer Agreement
+		return armcostmanagement.GranularityType("Hourly")
 	case "DAILY":
 		return armcostmanagement.GranularityTypeDaily
 	default:
@@ -106,107 +110,193 @@ func getColumnsFromQueryContext(qc *plugin.QueryContext) []*string {
 	return columns
 }
 
-// getPeriodTimeRange extracts start and end dates from period_start and period_end quals (following AWS pattern)
-func getPeriodTimeRange(keyQuals *plugin.QueryData, granularity string) (string, string) {
-	timeFormat := "2006-01-02"
+// getPeriodTimeRange derives the time period of the cost query from the period_start and period_end quals.
+//
+// The quals on both columns are treated as bounds of a single time window: '=', '>=' and '>' on period_start
+// and '>=' and '>' on period_end raise the start of the window, while '=', '<=' and '<' on period_end and
+// '<=' and '<' on period_start lower its end. This makes queries like
+// "period_end between current_date - interval '30d' and current_date" fetch only the days they need.
+// Rows report the resulting window in their period_start and period_end columns, which always satisfies the quals.
+//
+// Defaults to 11 months and 30 days ago through yesterday, or to the last 7 days through yesterday for hourly costs.
+func getPeriodTimeRange(keyQuals *plugin.QueryData, granularity string) (time.Time, time.Time) {
+	unit := 24 * time.Hour
 	if granularity == "HOURLY" {
-		timeFormat = "2006-01-02T15:04:05Z"
+		unit = time.Hour
 	}
 
-	st, et := "", ""
-
-	// Extract period_start - support multiple operators
-	if keyQuals.Quals["period_start"] != nil && len(keyQuals.Quals["period_start"].Quals) <= 1 {
-		for _, q := range keyQuals.Quals["period_start"].Quals {
-			t := q.Value.GetTimestampValue().AsTime()
-			timeStr := t.Format(timeFormat)
-			switch q.Operator {
-			case "=":
-				st = timeStr
-			}
+	var st, et time.Time
+	raiseStart := func(t time.Time) {
+		if st.IsZero() || t.After(st) {
+			st = t
+		}
+	}
+	lowerEnd := func(t time.Time) {
+		if et.IsZero() || t.Before(et) {
+			et = t
 		}
 	}
 
-	// The API supports a single value with the '=' operator.
-	// For queries like: "period_end BETWEEN current_timestamp - interval '31d' AND current_timestamp - interval '1d'", the FDW parses the query parameters with multiple qualifiers.
-	// In this case, we will have multiple qualifiers with operators such as:
-	// 1. The length of keyQuals.Quals["period_end"].Quals will be 2.
-	// 2. The qualifier values would be "2024-05-10" with the '>=" operator and "2024-06-09" with the '<=' operator.
-	// In this scenario, manipulating the start and end time is a bit difficult and challenging.
-	// Let the API fetch all the rows, and filtering will occur at the Steampipe level.
-
-	// Extract period_end - support multiple operators
-	if keyQuals.Quals["period_end"] != nil && len(keyQuals.Quals["period_end"].Quals) <= 1 {
-		for _, q := range keyQuals.Quals["period_end"].Quals {
-			t := q.Value.GetTimestampValue().AsTime()
-			timeStr := t.Format(timeFormat)
+	for _, column := range []string{"period_start", "period_end"} {
+		if keyQuals.Quals[column] == nil {
+			continue
+		}
+		for _, q := range keyQuals.Quals[column].Quals {
+			t := q.Value.GetTimestampValue().AsTime().UTC()
 			switch q.Operator {
 			case "=":
-				et = timeStr
+				if column == "period_start" {
+					raiseStart(t)
+				} else {
+					lowerEnd(t)
+				}
+			case ">=":
+				raiseStart(t)
+			case ">":
+				// Move to the next period boundary, so that the start of the window is strictly after the value
+				raiseStart(t.Truncate(unit).Add(unit))
+			case "<=":
+				lowerEnd(t)
+			case "<":
+				// Move to the previous period boundary, so that the end of the window is strictly before the value
+				if t.Equal(t.Truncate(unit)) {
+					lowerEnd(t.Add(-unit))
+				} else {
+					lowerEnd(t.Truncate(unit))
+				}
 			}
 		}
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 
 	// Set defaults if not provided
-	if st == "" {
-		st = now.AddDate(0, -11, -30).Format(timeFormat) // 11 months 30 days ago
+	if et.IsZero() {
+		et = now.AddDate(0, 0, -1).Truncate(unit) // Yesterday
 	}
-	if et == "" {
-		et = now.AddDate(0, 0, -1).Format(timeFormat) // Yesterday
+	if st.IsZero() && granularity == "HOURLY" {
+		st = et.AddDate(0, 0, -7) // 7 days before the end, as hourly results are 24 times larger
+	}
+	if st.IsZero() {
+		st = now.AddDate(0, -11, -30).Truncate(unit) // 11 months 30 days ago
+		if st.After(et) {
+			st = et.AddDate(0, -11, -30)
+		}
 	}
 
 	return st, et
 }
 
-// buildFilterExpression creates filter expressions from table key column quals
-func buildFilterExpression(d *plugin.QueryData, dimensionName string) *armcostmanagement.QueryFilter {
-	var filters []*armcostmanagement.QueryFilter
-	// Process dimension-specific quals (like service_name = 'Storage')
-	for _, keyQual := range d.Table.List.KeyColumns {
-		if keyQual.Name == "usage_date" || keyQual.Name == "scope" || keyQual.Name == "cost_type" || keyQual.Name == "period_start" || keyQual.Name == "period_end" {
-			continue // Skip time, scope, type quals
+// splitCostTimePeriod splits a time period longer than one year, which is the longest time period supported by
+// the Query API, into consecutive windows of at most one year. Shorter periods are queried in a single window.
+// Windows after the first start on the first day of a month, so that monthly costs are never split across two queries.
+func splitCostTimePeriod(start time.Time, end time.Time) []armcostmanagement.QueryTimePeriod {
+	if !start.AddDate(1, 0, 0).Before(end) {
+		return []armcostmanagement.QueryTimePeriod{{From: to.Ptr(start), To: to.Ptr(end)}}
+	}
+
+	var periods []armcostmanagement.QueryTimePeriod
+	for !start.After(end) {
+		next := start.AddDate(1, 0, 0)
+		next = time.Date(next.Year(), next.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+		windowEnd := next.Add(-time.Second)
+		if windowEnd.After(end) {
+			windowEnd = end
 		}
+		periods = append(periods, armcostmanagement.QueryTimePeriod{
+			From: to.Ptr(start),
+			To:   to.Ptr(windowEnd),
+		})
+		start = next
+	}
+	return periods
+}
+
+// costDimensionColumns maps the dimension columns of the cost tables to the Azure Cost Management dimension they filter on
+var costDimensionColumns = map[string]string{
+	"service_name":      "ServiceName",
+	"resource_group":    "ResourceGroupName",
+	"resource_id":       "ResourceId",
+	"resource_location": "ResourceLocation",
+	"meter":             "Meter",
+}
+
+// buildFilterExpression creates filter expressions from the quals on the given dimension columns.
+// dimensionColumns maps each column name to the Azure Cost Management dimension it filters on.
+//
+// '=' and IN quals are pushed down as an 'In' comparison on the dimension, and quals on different
+// columns are combined with 'And'. The Query API has no negation (the 'Not' expression was removed
+// after API version 2019-10-01), so '<>' and NOT IN quals are not pushed down and are applied at
+// the Steampipe level instead.
+func buildFilterExpression(d *plugin.QueryData, dimensionColumns map[string]string) *armcostmanagement.QueryFilter {
+	// Iterate over the columns in a stable order, so that the same query always results in the same filter
+	columnNames := make([]string, 0, len(dimensionColumns))
+	for columnName := range dimensionColumns {
+		columnNames = append(columnNames, columnName)
+	}
+	slices.Sort(columnNames)
 
-		filterQual := d.Quals[keyQual.Name]
-		if filterQual == nil {
+	var filters []*armcostmanagement.QueryFilter
+	for _, columnName := range columnNames {
+		dimensionName := dimensionColumns[columnName]
+		if dimensionName == "" || d.Quals[columnName] == nil {
 			continue
 		}
 
-		for _, qual := range filterQual.Quals {
-			if qual.Value != nil {
-				value := qual.Value.GetStringValue()
-				if value == "" {
-					continue
-				}
-
-				switch qual.Operator {
-				case "=":
-					filter := &armcostmanagement.QueryFilter{
-						Dimensions: &armcostmanagement.QueryComparisonExpression{
-							Name:     to.Ptr(dimensionName),
-							Operator: to.Ptr(armcostmanagement.QueryOperatorTypeIn),
-							Values:   []*string{to.Ptr(value)},
-						},
-					}
-					filters = append(filters, filter)
-				case "<>":
-					// Azure doesn't have a Not field, so we'll use a separate filter for exclusions
-					// For now, we'll skip <> operators or handle them differently
-					// TODO: Implement exclusion logic using different approach
-					continue
-				}
+		for _, qual := range d.Quals[columnName].Quals {
+			if qual.Operator != "=" {
+				continue
+			}
+			values := getCostQualValues(qual)
+			if len(values) == 0 {
+				continue
 			}
+			filters = append(filters, &armcostmanagement.QueryFilter{
+				Dimensions: &armcostmanagement.QueryComparisonExpression{
+					Name:     to.Ptr(dimensionName),
+					Operator: to.Ptr(armcostmanagement.QueryOperatorTypeIn),
+					Values:   values,
+				},
+			})
 		}
 	}
 
-	// Combine filters with AND logic
-	if len(filters) == 0 {
+	return combineCostFilters(filters)
+}
+
+// getCostQualValues returns the values of a qual, which is a list for IN and NOT IN quals.
+// Returns nil if any value is empty, since empty dimension values can't be expressed as a filter.
+func getCostQualValues(qual *quals.Qual) []*string {
+	if qual.Value == nil {
 		return nil
-	} else if len(filters) == 1 {
-		return filters[0]
+	}
+
+	var values []*string
+	if listValue := qual.Value.GetListValue(); listValue != nil {
+		for _, v := range listValue.Values {
+			values = append(values, to.Ptr(v.GetStringValue()))
+		}
 	} else {
+		values = append(values, to.Ptr(qual.Value.GetStringValue()))
+	}
+
+	for _, v := range values {
+		if *v == "" {
+			return nil
+		}
+	}
+	return values
+}
+
+// combineCostFilters combines filter expressions with 'And', which requires at least 2 items
+func combineCostFilters(filters []*armcostmanagement.QueryFilter) *armcostmanagement.QueryFilter {
+	switch len(filters) {
+	case 0:
+		return nil
+	case 1:
+		return filters[0]
+	default:
 		return &armcostmanagement.QueryFilter{
 			And: filters,
 		}
@@ -224,15 +314,15 @@ func costManagementColumns(columns []*plugin.Column) []*plugin.Column {
 		},
 		{
 			Name:        "period_start",
-			Description: "The start date of the period, populated if specified in query parameters.",
+			Description: "The start of the time period queried, derived from the period_start and period_end quals.",
 			Type:        proto.ColumnType_TIMESTAMP,
-			Transform:   transform.FromQual("period_start"),
+			Transform:   transform.FromField("PeriodStart"),
 		},
 		{
 			Name:        "period_end",
-			Description: "The end date of the period, populated if specified in query parameters.",
+			Description: "The end of the time period queried, derived from the period_start and period_end quals.",
 			Type:        proto.ColumnType_TIMESTAMP,
-			Transform:   transform.FromQual("period_end"),
+			Transform:   transform.FromField("PeriodEnd"),
 		},
 		// estimated removed
 		{
@@ -265,6 +355,30 @@ func costManagementColumns(columns []*plugin.Column) []*plugin.Column {
 			Type:        proto.ColumnType_STRING,
 			Transform:   transform.FromQual("cost_type"),
 		},
+		{
+			Name:        "billing_account_id",
+			Description: "The billing account to query costs for. Combine with billing_profile_id and invoice_section_id to narrow the scope.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromQual("billing_account_id"),
+		},
+		{
+			Name:        "billing_profile_id",
+			Description: "The billing profile to query costs for. Requires billing_account_id.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromQual("billing_profile_id"),
+		},
+		{
+			Name:        "invoice_section_id",
+			Description: "The invoice section to query costs for. Requires billing_account_id and billing_profile_id.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromQual("invoice_section_id"),
+		},
+		{
+			Name:        "management_group_id",
+			Description: "The management group to query costs for.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromQual("management_group_id"),
+		},
 	}
 
 	// Prepend table-specific columns to standard columns
@@ -284,21 +398,91 @@ func costManagementKeyColumns() plugin.KeyColumnSlice {
 			Require:   plugin.Required,
 			Operators: []string{"="},
 		},
+		{
+			Name:      "billing_account_id",
+			Require:   plugin.Optional,
+			Operators: []string{"="},
+		},
+		{
+			Name:      "billing_profile_id",
+			Require:   plugin.Optional,
+			Operators: []string{"="},
+		},
+		{
+			Name:      "invoice_section_id",
+			Require:   plugin.Optional,
+			Operators: []string{"="},
+		},
+		{
+			Name:      "management_group_id",
+			Require:   plugin.Optional,
+			Operators: []string{"="},
+		},
 		{
 			Name:       "period_start",
 			Require:    plugin.Optional,
-			Operators:  []string{"="},
+			Operators:  []string{"=", ">", ">=", "<", "<="},
 			CacheMatch: query_cache.CacheMatchExact,
 		},
 		{
 			Name:       "period_end",
 			Require:    plugin.Optional,
-			Operators:  []string{"="},
+			Operators:  []string{"=", ">", ">=", "<", "<="},
 			CacheMatch: query_cache.CacheMatchExact,
 		},
 	}
 }
 
+// getCostScopeFromQuals returns the scope to query from the scope, billing and management group quals.
+// An empty string is returned if none of them are specified.
+// https://learn.microsoft.com/en-us/azure/cost-management-billing/costs/understand-work-scopes
+func getCostScopeFromQuals(d *plugin.QueryData) (string, error) {
+	scope := d.EqualsQualString("scope")
+	billingAccountID := d.EqualsQualString("billing_account_id")
+	billingProfileID := d.EqualsQualString("billing_profile_id")
+	invoiceSectionID := d.EqualsQualString("invoice_section_id")
+	managementGroupID := d.EqualsQualString("management_group_id")
+
+	var scopes []string
+	if scope != "" {
+		scopes = append(scopes, "scope")
+	}
+	if billingAccountID != "" {
+		scopes = append(scopes, "billing_account_id")
+	}
+	if managementGroupID != "" {
+		scopes = append(scopes, "management_group_id")
+	}
+	if len(scopes) > 1 {
+		return "", fmt.Errorf("only one of %s can be specified", strings.Join(scopes, ", "))
+	}
+
+	if billingAccountID == "" && (billingProfileID != "" || invoiceSectionID != "") {
+		return "", fmt.Errorf("billing_account_id must be specified with billing_profile_id or invoice_section_id")
+	}
+	if billingProfileID == "" && invoiceSectionID != "" {
+		return "", fmt.Errorf("billing_profile_id must be specified with invoice_section_id")
+	}
+
+	switch {
+	case scope != "":
+		return scope, nil
+	case managementGroupID != "":
+		return "/providers/Microsoft.Management/managementGroups/" + managementGroupID, nil
+	case billingAccountID != "":
+		scope = "/providers/Microsoft.Billing/billingAccounts/" + billingAccountID
+		if billingProfileID != "" {
+			scope += "/billingProfiles/" + billingProfileID
+		}
+		if invoiceSectionID != "" {
+			scope += "/invoiceSections/" + invoiceSectionID
+		}
+		return scope, nil
+	}
+
+	return "", nil
+}
+
 // getCostManagementClient creates a new cost management client
 func getCostManagementClient(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (*armcostmanagement.QueryClient, error) {
 	session, err := GetNewSessionUpdated(ctx, d)
@@ -316,51 +500,71 @@ func getCostManagementClient(ctx context.Context, d *plugin.QueryData, h *plugin
 
 // streamCostAndUsage is the generic function for streaming cost data (like AWS)
 func streamCostAndUsage(ctx context.Context, d *plugin.QueryData, queryDef armcostmanagement.QueryDefinition, scope string, groupingNames ...string) (interface{}, error) {
+	rowMap, err := getCostAndUsage(ctx, d, queryDef, scope, groupingNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stream results
+	for _, row := range rowMap {
+		if queryDef.TimePeriod != nil {
+			row.PeriodStart = queryDef.TimePeriod.From
+			row.PeriodEnd = queryDef.TimePeriod.To
+		}
+		d.StreamListItem(ctx, *row)
+
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getCostAndUsage runs the cost query and returns the result rows keyed by usage date and dimension values
+func getCostAndUsage(ctx context.Context, d *plugin.QueryData, queryDef armcostmanagement.QueryDefinition, scope string, groupingNames ...string) (map[string]*CostManagementRow, error) {
 	client, err := getCostManagementClient(ctx, d, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// Resolve scope if it's a placeholder or extract from quals
+	// Resolve the placeholder to the connection's subscription when no scope was given in the quals
 	if scope == "/subscriptions/placeholder" {
-		// Check if scope is provided in quals
-		scopeQual := d.EqualsQualString("scope")
-		if scopeQual != "" {
-			scope = scopeQual
-		} else {
-			// Fallback to subscription ID if no scope provided
-			subscriptionData, err := getSubscriptionID(ctx, d, nil)
-			if err != nil {
-				return nil, err
-			}
-			subscriptionID := subscriptionData.(string)
-			scope = "/subscriptions/" + subscriptionID
+		subscriptionData, err := getSubscriptionID(ctx, d, nil)
+		if err != nil {
+			return nil, err
 		}
+		subscriptionID := subscriptionData.(string)
+		scope = "/subscriptions/" + subscriptionID
 	}
 
-	// Execute query with a single API call
+	// The Query API supports time periods of up to one year, so longer periods are
+	// split into sequential queries whose results are merged into the same row map
 	rowMap := make(map[string]*CostManagementRow)
 
-	plugin.Logger(ctx).Debug("Making Azure Cost Management API call", "query", queryDef, "scope", scope)
-
-	result, err := client.Usage(ctx, scope, queryDef, nil)
-	if err != nil {
-		plugin.Logger(ctx).Error("Azure Cost Management Query failed", "error", err)
-		return nil, err
+	periods := []armcostmanagement.QueryTimePeriod{{}}
+	if queryDef.TimePeriod != nil && queryDef.TimePeriod.From != nil && queryDef.TimePeriod.To != nil {
+		periods = splitCostTimePeriod(*queryDef.TimePeriod.From, *queryDef.TimePeriod.To)
 	}
 
-	processQueryResults(&result.QueryResult, scope, rowMap, groupingNames...)
+	for _, period := range periods {
+		periodQueryDef := queryDef
+		if period.From != nil {
+			periodQueryDef.TimePeriod = to.Ptr(period)
+		}
 
-	// Stream results
-	for _, row := range rowMap {
-		d.StreamListItem(ctx, *row)
+		plugin.Logger(ctx).Debug("Making Azure Cost Management API call", "query", periodQueryDef, "scope", scope)
 
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
+		result, err := client.Usage(ctx, scope, periodQueryDef, nil)
+		if err != nil {
+			plugin.Logger(ctx).Error("Azure Cost Management Query failed", "error", err)
+			return nil, err
 		}
+
+		processQueryResults(&result.QueryResult, scope, rowMap, groupingNames...)
 	}
 
-	return nil, nil
+	return rowMap, nil
 }
 
 // processQueryResults processes query results and merges them into the row map
@@ -419,7 +623,12 @@ func processQueryResults(result *armcostmanagement.QueryResult, scope string, ro
 					}
 				}
 			} else if strings.Contains(usageDateStr, "T") {
-				usageDateStr = strings.Split(usageDateStr, "T")[0]
+				// Hourly results are timestamps, keep the hour so that the rows of a day are not merged
+				if t, err := time.Parse("2006-01-02T15:04:05", strings.TrimSuffix(usageDateStr, "Z")); err == nil && (t.Hour() != 0 || t.Minute() != 0) {
+					usageDateStr = t.Format("2006-01-02T15:04:05")
+				} else {
+					usageDateStr = strings.Split(usageDateStr, "T")[0]
+				}
 			}
 		} else { // BillingMonth -> normalize to last day of month
 			usageDateStr = getString(row, "BillingMonth")
@@ -467,7 +676,11 @@ func processQueryResults(result *armcostmanagement.QueryResult, scope string, ro
 		if usageDateStr == "" {
 			continue
 		}
-		parsedDate, err := time.Parse("2006-01-02", usageDateStr)
+		layout := "2006-01-02"
+		if strings.Contains(usageDateStr, "T") {
+			layout = "2006-01-02T15:04:05"
+		}
+		parsedDate, err := time.Parse(layout, usageDateStr)
 		if err != nil {
 			continue
 		}
@@ -535,7 +748,10 @@ func processQueryResults(result *armcostmanagement.QueryResult, scope string, ro
 // buildForecastQueryInput builds input parameters specifically for forecast tables
 func buildForecastQueryInput(ctx context.Context, d *plugin.QueryData, granularity string) (armcostmanagement.ForecastDefinition, string, error) {
 	// Get scope from quals
-	scope := d.EqualsQualString("scope")
+	scope, err := getCostScopeFromQuals(d)
+	if err != nil {
+		return armcostmanagement.ForecastDefinition{}, "", err
+	}
 	if scope == "" {
 		// Get subscription ID
 		subscriptionID, err := getSubscriptionID(ctx, d, nil)
@@ -646,7 +862,7 @@ func streamForecastUsage(ctx context.Context, d *plugin.QueryData, forecastDef a
 		return nil, err
 	}
 
-	err = streamForecastResults(ctx, d, &result, scope, granularity)
+	err = streamForecastResults(ctx, d, &result, forecastDef, scope, granularity)
 	if err != nil {
 		plugin.Logger(ctx).Error("azure_cost_forecast.streamForecastUsage", "stream_error", err)
 		return nil, err
@@ -655,11 +871,21 @@ func streamForecastUsage(ctx context.Context, d *plugin.QueryData, forecastDef a
 }
 
 // streamForecastResults handles forecast API results specifically
-func streamForecastResults(ctx context.Context, d *plugin.QueryData, result *armcostmanagement.ForecastClientUsageResponse, scope string, granularity string) error {
+func streamForecastResults(ctx context.Context, d *plugin.QueryData, result *armcostmanagement.ForecastClientUsageResponse, forecastDef armcostmanagement.ForecastDefinition, scope string, granularity string) error {
 	if result.Properties == nil || result.Properties.Rows == nil {
 		return nil
 	}
 
+	// A forecast always starts today, so report the qual value for an '=' qual on the
+	// period columns and the forecast time period otherwise
+	periodStart, periodEnd := forecastDef.TimePeriod.From, forecastDef.TimePeriod.To
+	if t := getSingleEqualsTimestampQual(d, "period_start"); t != nil {
+		periodStart = t
+	}
+	if t := getSingleEqualsTimestampQual(d, "period_end"); t != nil {
+		periodEnd = t
+	}
+
 	for _, row := range result.Properties.Rows {
 		if len(row) < 4 {
 			continue
@@ -669,6 +895,12 @@ func streamForecastResults(ctx context.Context, d *plugin.QueryData, result *arm
 		costRow := &CostManagementRow{
 			Scope: &scope,
 		}
+		if periodStart != nil {
+			costRow.PeriodStart = periodStart
+		}
+		if periodEnd != nil {
+			costRow.PeriodEnd = periodEnd
+		}
 
 		// Parse date - can be either YYYYMMDD format (daily) or RFC3339/BillingMonth (monthly)
 		var usageDate time.Time
@@ -735,10 +967,22 @@ func streamForecastResults(ctx context.Context, d *plugin.QueryData, result *arm
 	return nil
 }
 
+// getSingleEqualsTimestampQual returns the value of the qual on a timestamp column if it is a single '=' qual
+func getSingleEqualsTimestampQual(d *plugin.QueryData, column string) *time.Time {
+	if d.Quals[column] == nil || len(d.Quals[column].Quals) != 1 || d.Quals[column].Quals[0].Operator != "=" {
+		return nil
+	}
+	t := d.Quals[column].Quals[0].Value.GetTimestampValue().AsTime()
+	return &t
+}
+
 // buildCostQueryInput is a common function to build input parameters for all cost tables
 func buildCostQueryInput(ctx context.Context, d *plugin.QueryData, granularity string, groupingNames []string) (armcostmanagement.QueryDefinition, string, error) {
 	// Get scope from quals, default to placeholder if not provided
-	scope := d.EqualsQualString("scope")
+	scope, err := getCostScopeFromQuals(d)
+	if err != nil {
+		return armcostmanagement.QueryDefinition{}, "", err
+	}
 	if scope == "" {
 		scope = "/subscriptions/placeholder" // Will be resolved in streamCostAndUsage
 	}
@@ -754,16 +998,7 @@ func buildCostQueryInput(ctx context.Context, d *plugin.QueryData, granularity s
 	timePeriod := &armcostmanagement.QueryTimePeriod{}
 
 	// Get time range from period_start/period_end quals
-	startTime, endTime := getPeriodTimeRange(d, granularity)
-
-	startDate, err := time.Parse("2006-01-02", startTime)
-	if err != nil {
-		return armcostmanagement.QueryDefinition{}, "", fmt.Errorf("failed to parse start date: %v", err)
-	}
-	endDate, err := time.Parse("2006-01-02", endTime)
-	if err != nil {
-		return armcostmanagement.QueryDefinition{}, "", fmt.Errorf("failed to parse end date: %v", err)
-	}
+	startDate, endDate := getPeriodTimeRange(d, granularity)
 
 	timePeriod.From = to.Ptr(startDate)
 	timePeriod.To = to.Ptr(endDate)
@@ -779,11 +1014,14 @@ func buildCostQueryInput(ctx context.Context, d *plugin.QueryData, granularity s
 		})
 	}
 
-	// Build filter expressions from quals (use first grouping name for filter)
-	var filter *armcostmanagement.QueryFilter
-	if len(groupingNames) > 0 {
-		filter = buildFilterExpression(d, groupingNames[0])
+	// Build filter expressions from the quals on the dimension columns of the table
+	dimensionColumns := map[string]string{}
+	for _, keyColumn := range d.Table.List.KeyColumns {
+		if dimensionName, ok := costDimensionColumns[keyColumn.Name]; ok {
+			dimensionColumns[keyColumn.Name] = dimensionName
+		}
 	}
+	filter := buildFilterExpression(d, dimensionColumns)
 
 	// Build aggregation based on requested columns
 	aggregation := make(map[string]*armcostmanagement.QueryAggregation)
@@ -827,3 +1065,113 @@ func buildCostQueryInput(ctx context.Context, d *plugin.QueryData, granularity s
 
 	return queryDef, scope, nil
 }
+
+// buildCostByTagQueryInput builds input parameters for the cost by tag tables, grouping costs by the values of the tag given in the tag_key qual
+func buildCostByTagQueryInput(ctx context.Context, d *plugin.QueryData, granularity string) (armcostmanagement.QueryDefinition, string, error) {
+	tagKey := d.EqualsQualString("tag_key")
+	if tagKey == "" {
+		return armcostmanagement.QueryDefinition{}, "", fmt.Errorf("missing required qual 'tag_key'")
+	}
+
+	queryDef, scope, err := buildCostQueryInput(ctx, d, granularity, nil)
+	if err != nil {
+		return armcostmanagement.QueryDefinition{}, "", err
+	}
+
+	queryDef.Dataset.Grouping = []*armcostmanagement.QueryGrouping{
+		{
+			Type: to.Ptr(armcostmanagement.QueryColumnTypeTagKey),
+			Name: to.Ptr(tagKey),
+		},
+	}
+
+	if filter := buildTagFilterExpression(d, tagKey); filter != nil {
+		if queryDef.Dataset.Filter != nil {
+			filter = combineCostFilters([]*armcostmanagement.QueryFilter{queryDef.Dataset.Filter, filter})
+		}
+		queryDef.Dataset.Filter = filter
+	}
+
+	return queryDef, scope, nil
+}
+
+// buildTagFilterExpression creates a Tags filter expression from the tag_value quals.
+// As for dimensions, only '=' and IN quals can be pushed down.
+func buildTagFilterExpression(d *plugin.QueryData, tagKey string) *armcostmanagement.QueryFilter {
+	if d.Quals["tag_value"] == nil {
+		return nil
+	}
+
+	var filters []*armcostmanagement.QueryFilter
+	for _, qual := range d.Quals["tag_value"].Quals {
+		if qual.Operator != "=" {
+			continue
+		}
+		// Untagged costs are returned with an empty tag value, which can't be expressed as a filter.
+		// Let the API return all rows and filtering will occur at the Steampipe level.
+		values := getCostQualValues(qual)
+		if len(values) == 0 {
+			continue
+		}
+		filters = append(filters, &armcostmanagement.QueryFilter{
+			Tags: &armcostmanagement.QueryComparisonExpression{
+				Name:     to.Ptr(tagKey),
+				Operator: to.Ptr(armcostmanagement.QueryOperatorTypeIn),
+				Values:   values,
+			},
+		})
+	}
+
+	return combineCostFilters(filters)
+}
+
+// costByTagColumns returns the tag columns shared by the cost by tag tables
+func costByTagColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "tag_key",
+			Description: "The tag key the costs are grouped by.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromQual("tag_key"),
+		},
+		{
+			Name:        "tag_value",
+			Description: "The value of the tag. Costs of resources without the tag are returned with an empty value.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.FromField("Dimension1").Transform(costTagValue),
+		},
+	}
+}
+
+// costByTagKeyColumns returns the key columns of the cost by tag tables
+func costByTagKeyColumns() plugin.KeyColumnSlice {
+	return append(costManagementKeyColumns(),
+		&plugin.KeyColumn{
+			Name:       "tag_key",
+			Require:    plugin.Required,
+			Operators:  []string{"="},
+			CacheMatch: query_cache.CacheMatchExact,
+		},
+		&plugin.KeyColumn{
+			Name:      "tag_value",
+			Require:   plugin.Optional,
+			Operators: []string{"=", "<>"},
+		},
+	)
+}
+
+//// TRANSFORM FUNCTIONS
+
+// costTagValue returns an empty string for untagged costs, so that they are not dropped when filtering or joining on tag_value
+func costTagValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	switch v := d.Value.(type) {
+	case nil:
+		return "", nil
+	case *string:
+		if v == nil {
+			return "", nil
+		}
+		return *v, nil
+	}
+	return d.Value, nil
+}
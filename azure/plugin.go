@@ -129,13 +129,19 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_app_service_web_app_slot":                               tableAzureAppServiceWebAppSlot(ctx),
 			"azure_application_gateway":                                    tableAzureApplicationGateway(ctx),
 			"azure_application_insight":                                    tableAzureApplicationInsight(ctx),
+			"azure_application_insight_availability_result":                tableAzureApplicationInsightAvailabilityResult(ctx),
+			"azure_application_insight_exception":                          tableAzureApplicationInsightException(ctx),
+			"azure_application_insight_request":                            tableAzureApplicationInsightRequest(ctx),
 			"azure_application_security_group":                             tableAzureApplicationSecurityGroup(ctx),
 			"azure_automation_account":                                     tableAzureApAutomationAccount(ctx),
 			"azure_automation_variable":                                    tableAzureApAutomationVariable(ctx),
 			"azure_backup_policy":                                          tableAzureBackupPolicy(ctx),
 			"azure_bastion_host":                                           tableAzureBastionHost(ctx),
 			"azure_batch_account":                                          tableAzureBatchAccount(ctx),
+			"azure_billing_account":                                        tableAzureBillingAccount(ctx),
+			"azure_billing_profile":                                        tableAzureBillingProfile(ctx),
 			"azure_cdn_frontdoor_profile":                                  tableAzureCDNFrontDoorProfile(ctx),
+			"azure_classic_administrator":                                  tableAzureClassicAdministrator(ctx),
 			"azure_cognitive_account":                                      tableAzureCognitiveAccount(ctx),
 			"azure_compute_availability_set":                               tableAzureComputeAvailabilitySet(ctx),
 			"azure_compute_disk":                                           tableAzureComputeDisk(ctx),
@@ -162,6 +168,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_compute_virtual_machine_scale_set_network_interface":    tableAzureComputeVirtualMachineScaleSetNetworkInterface(ctx),
 			"azure_compute_virtual_machine_scale_set_vm":                   tableAzureComputeVirtualMachineScaleSetVm(ctx),
 			"azure_compute_virtual_machine_size":                           tableAzureComputeVirtualMachineSize(ctx),
+			"azure_consumption_budget":                                     tableAzureConsumptionBudget(ctx),
 			"azure_consumption_usage":                                      tableAzureConsumptionUsage(ctx),
 			"azure_container_group":                                        tableAzureContainerGroup(ctx),
 			"azure_container_registry":                                     tableAzureContainerRegistry(ctx),
@@ -170,12 +177,18 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_cosmosdb_mongo_database":                                tableAzureCosmosDBMongoDatabase(ctx),
 			"azure_cosmosdb_restorable_database_account":                   tableAzureCosmosDBRestorableDatabaseAccount(ctx),
 			"azure_cosmosdb_sql_database":                                  tableAzureCosmosDBSQLDatabase(ctx),
+			"azure_cost_anomaly":                                           tableAzureCostAnomaly(ctx),
+			"azure_cost_by_resource_daily":                                 tableAzureCostByResourceDaily(ctx),
 			"azure_cost_by_resource_group_daily":                           tableAzureCostByResourceGroupDaily(ctx),
 			"azure_cost_by_resource_group_monthly":                         tableAzureCostByResourceGroupMonthly(ctx),
 			"azure_cost_by_service_daily":                                  tableAzureCostByServiceDaily(ctx),
 			"azure_cost_by_service_monthly":                                tableAzureCostByServiceMonthly(ctx),
+			"azure_cost_by_tag_daily":                                      tableAzureCostByTagDaily(ctx),
+			"azure_cost_by_tag_monthly":                                    tableAzureCostByTagMonthly(ctx),
 			"azure_cost_forecast_daily":                                    tableCostForecastDaily(ctx),
 			"azure_cost_forecast_monthly":                                  tableCostForecastMonthly(ctx),
+			"azure_cost_management_export":                                 tableAzureCostManagementExport(ctx),
+			"azure_cost_management_view":                                   tableAzureCostManagementView(ctx),
 			"azure_cost_usage":                                             tableAzureCostUsage(ctx),
 			"azure_data_factory":                                           tableAzureDataFactory(ctx),
 			"azure_data_factory_dataset":                                   tableAzureDataFactoryDataset(ctx),
@@ -186,6 +199,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_data_protection_backup_vault":                           tableAzureDataProtectionBackupVault(ctx),
 			"azure_databox_edge_device":                                    tableAzureDataBoxEdgeDevice(ctx),
 			"azure_databricks_workspace":                                   tableAzureDatabricksWorkspace(ctx),
+			"azure_deny_assignment":                                        tableAzureDenyAssignment(ctx),
 			"azure_diagnostic_setting":                                     tableAzureDiagnosticSetting(ctx),
 			"azure_dns_zone":                                               tableAzureDNSZone(ctx),
 			"azure_eventgrid_domain":                                       tableAzureEventGridDomain(ctx),
@@ -205,6 +219,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_key_vault":                                              tableAzureKeyVault(ctx),
 			"azure_key_vault_certificate":                                  tableAzureKeyVaultCertificate(ctx),
 			"azure_key_vault_deleted_vault":                                tableAzureKeyVaultDeletedVault(ctx),
+			"azure_key_vault_expiring_item":                                tableAzureKeyVaultExpiringItem(ctx),
 			"azure_key_vault_key":                                          tableAzureKeyVaultKey(ctx),
 			"azure_key_vault_key_version":                                  tableAzureKeyVaultKeyVersion(ctx),
 			"azure_key_vault_managed_hardware_security_module":             tableAzureKeyVaultManagedHardwareSecurityModule(ctx),
@@ -222,6 +237,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_lighthouse_definition":                                  tableAzureLighthouseDefinition(ctx),
 			"azure_location":                                               tableAzureLocation(ctx),
 			"azure_log_alert":                                              tableAzureLogAlert(ctx),
+			"azure_log_analytics_query":                                    tableAzureLogAnalyticsQuery(ctx),
 			"azure_log_analytics_workspace":                                tableAzureLogAnalyticsWorkspace(ctx),
 			"azure_log_profile":                                            tableAzureLogProfile(ctx),
 			"azure_logic_app_workflow":                                     tableAzureLogicAppWorkflow(ctx),
@@ -230,8 +246,15 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_management_group":                                       tableAzureManagementGroup(ctx),
 			"azure_management_lock":                                        tableAzureManagementLock(ctx),
 			"azure_mariadb_server":                                         tableAzureMariaDBServer(ctx),
+			"azure_monitor_action_group":                                   tableAzureMonitorActionGroup(ctx),
 			"azure_monitor_activity_log_event":                             tableAzureMonitorActivityLogEvent(ctx),
+			"azure_monitor_alert_routing":                                  tableAzureMonitorAlertRouting(ctx),
+			"azure_monitor_data_collection_endpoint":                       tableAzureMonitorDataCollectionEndpoint(ctx),
+			"azure_monitor_data_collection_rule":                           tableAzureMonitorDataCollectionRule(ctx),
+			"azure_monitor_data_collection_rule_association":               tableAzureMonitorDataCollectionRuleAssociation(ctx),
 			"azure_monitor_log_profile":                                    tableAzureMonitorLogProfile(ctx),
+			"azure_monitor_metric_alert":                                   tableAzureMonitorMetricAlert(ctx),
+			"azure_monitor_scheduled_query_rule":                           tableAzureMonitorScheduledQueryRule(ctx),
 			"azure_mssql_elasticpool":                                      tableAzureMSSQLElasticPool(ctx),
 			"azure_mssql_managed_instance":                                 tableAzureMSSQLManagedInstance(ctx),
 			"azure_mssql_virtual_machine":                                  tableAzureMSSQLVirtualMachine(ctx),
@@ -245,8 +268,13 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_network_watcher_flow_log":                               tableAzureNetworkWatcherFlowLog(ctx),
 			"azure_policy_assignment":                                      tableAzurePolicyAssignment(ctx),
 			"azure_policy_definition":                                      tableAzurePolicyDefinition(ctx),
+			"azure_policy_exemption":                                       tableAzurePolicyExemption(ctx),
+			"azure_policy_remediation":                                     tableAzurePolicyRemediation(ctx),
+			"azure_policy_set_definition":                                  tableAzurePolicySetDefinition(ctx),
+			"azure_policy_state":                                           tableAzurePolicyState(ctx),
 			"azure_postgresql_flexible_server":                             tableAzurePostgreSqlFlexibleServer(ctx),
 			"azure_postgresql_server":                                      tableAzurePostgreSqlServer(ctx),
+			"azure_pricing":                                                tableAzurePricing(ctx),
 			"azure_private_dns_zone":                                       tableAzurePrivateDNSZone(ctx),
 			"azure_private_endpoint":                                       tableAzurePrivateEndpoint(ctx),
 			"azure_provider":                                               tableAzureProvider(ctx),
@@ -254,17 +282,34 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"azure_recovery_services_backup_job":                           tableAzureRecoveryServicesBackupJob(ctx),
 			"azure_recovery_services_vault":                                tableAzureRecoveryServicesVault(ctx),
 			"azure_redis_cache":                                            tableAzureRedisCache(ctx),
+			"azure_reservation":                                            tableAzureReservation(ctx),
+			"azure_reservation_recommendation":                             tableAzureReservationRecommendation(ctx),
+			"azure_reservation_utilization_daily":                          tableAzureReservationUtilizationDaily(ctx),
 			"azure_resource":                                               tableAzureResourceResource(ctx),
 			"azure_resource_group":                                         tableAzureResourceGroup(ctx),
 			"azure_resource_link":                                          tableAzureResourceLink(ctx),
 			"azure_role_assignment":                                        tableAzureIamRoleAssignment(ctx),
+			"azure_role_assignment_schedule_instance":                      tableAzureRoleAssignmentScheduleInstance(ctx),
 			"azure_role_definition":                                        tableAzureRoleDefinition(ctx),
+			"azure_role_effective_permission":                              tableAzureRoleEffectivePermission(ctx),
+			"azure_role_eligibility_schedule_instance":                     tableAzureRoleEligibilityScheduleInstance(ctx),
+			"azure_role_management_policy":                                 tableAzureRoleManagementPolicy(ctx),
 			"azure_route_table":                                            tableAzureRouteTable(ctx),
+			"azure_savings_plan":                                           tableAzureSavingsPlan(ctx),
+			"azure_savings_plan_recommendation":                            tableAzureSavingsPlanRecommendation(ctx),
+			"azure_savings_plan_utilization_daily":                         tableAzureSavingsPlanUtilizationDaily(ctx),
 			"azure_search_service":                                         tableAzureSearchService(ctx),
+			"azure_security_center_alert":                                  tableAzureSecurityCenterAlert(ctx),
+			"azure_security_center_assessment":                             tableAzureSecurityCenterAssessment(ctx),
 			"azure_security_center_auto_provisioning":                      tableAzureSecurityCenterAutoProvisioning(ctx),
 			"azure_security_center_automation":                             tableAzureSecurityCenterAutomation(ctx),
 			"azure_security_center_contact":                                tableAzureSecurityCenterContact(ctx),
 			"azure_security_center_jit_network_access_policy":              tableAzureSecurityCenterJITNetworkAccessPolicy(ctx),
+			"azure_security_center_regulatory_compliance_assessment":       tableAzureSecurityCenterRegulatoryComplianceAssessment(ctx),
+			"azure_security_center_regulatory_compliance_control":          tableAzureSecurityCenterRegulatoryComplianceControl(ctx),
+			"azure_security_center_regulatory_compliance_standard":         tableAzureSecurityCenterRegulatoryComplianceStandard(ctx),
+			"azure_security_center_secure_score":                           tableAzureSecurityCenterSecureScore(ctx),
+			"azure_security_center_secure_score_control":                   tableAzureSecurityCenterSecureScoreControl(ctx),
 			"azure_security_center_setting":                                tableAzureSecurityCenterSetting(ctx),
 			"azure_security_center_sub_assessment":                         tableAzureSecurityCenterSubAssessment(ctx),
 			"azure_security_center_subscription_pricing":                   tableAzureSecurityCenterPricing(ctx),
@@ -37,14 +37,16 @@ type SessionNew struct {
 }
 
 type Session struct {
-	Authorizer              autorest.Authorizer
-	CloudEnvironment        string
-	Expires                 *time.Time
-	GraphEndpoint           string
-	ResourceManagerEndpoint string
-	StorageEndpointSuffix   string
-	SubscriptionID          string
-	TenantID                string
+	ApplicationInsightsEndpoint string
+	Authorizer                  autorest.Authorizer
+	CloudEnvironment            string
+	Expires                     *time.Time
+	GraphEndpoint               string
+	OperationalInsightsEndpoint string
+	ResourceManagerEndpoint     string
+	StorageEndpointSuffix       string
+	SubscriptionID              string
+	TenantID                    string
 }
 
 /*
@@ -497,14 +499,16 @@ func GetNewSession(ctx context.Context, d *plugin.QueryData, tokenAudience strin
 	}
 
 	sess := &Session{
-		Authorizer:              authorizer,
-		CloudEnvironment:        settings.Environment.Name,
-		Expires:                 expiresOn,
-		GraphEndpoint:           settings.Environment.GraphEndpoint,
-		ResourceManagerEndpoint: settings.Environment.ResourceManagerEndpoint,
-		StorageEndpointSuffix:   settings.Environment.StorageEndpointSuffix,
-		SubscriptionID:          subscriptionID,
-		TenantID:                tenantID,
+		ApplicationInsightsEndpoint: getApplicationInsightsEndpoint(settings.Environment),
+		Authorizer:                  authorizer,
+		CloudEnvironment:            settings.Environment.Name,
+		Expires:                     expiresOn,
+		GraphEndpoint:               settings.Environment.GraphEndpoint,
+		OperationalInsightsEndpoint: settings.Environment.ResourceIdentifiers.OperationalInsights,
+		ResourceManagerEndpoint:     settings.Environment.ResourceManagerEndpoint,
+		StorageEndpointSuffix:       settings.Environment.StorageEndpointSuffix,
+		SubscriptionID:              subscriptionID,
+		TenantID:                    tenantID,
 	}
 
 	var expireMins time.Duration
@@ -560,6 +564,10 @@ func getApplicableAuthorizationDetails(ctx context.Context, settings auth.Enviro
 		resource = settings.Environment.GraphEndpoint
 	case "VAULT":
 		resource = strings.TrimSuffix(settings.Environment.KeyVaultEndpoint, "/")
+	case "LOG_ANALYTICS":
+		resource = settings.Environment.ResourceIdentifiers.OperationalInsights
+	case "APPLICATION_INSIGHTS":
+		resource = getApplicationInsightsEndpoint(settings.Environment)
 	case "MANAGEMENT":
 		resource = settings.Environment.ResourceManagerEndpoint
 	default:
@@ -571,6 +579,20 @@ func getApplicableAuthorizationDetails(ctx context.Context, settings auth.Enviro
 	return
 }
 
+// getApplicationInsightsEndpoint returns the Application Insights data plane endpoint for the environment.
+// The go-autorest environments do not define it, so it is resolved from the environment name.
+// https://learn.microsoft.com/en-us/azure/azure-monitor/app/app-insights-overview
+func getApplicationInsightsEndpoint(environment azure.Environment) string {
+	switch environment.Name {
+	case azure.USGovernmentCloud.Name:
+		return "https://api.applicationinsights.us"
+	case azure.ChinaCloud.Name:
+		return "https://api.applicationinsights.azure.cn"
+	default:
+		return "https://api.applicationinsights.io"
+	}
+}
+
 //// Retry config
 
 type RetryRule struct {
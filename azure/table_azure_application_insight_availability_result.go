@@ -0,0 +1,91 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Columns of the availabilityResults table that can be filtered in the KQL query
+var applicationInsightAvailabilityResultFilterColumns = map[string]string{
+	"name":            "name",
+	"location":        "location",
+	"cloud_role_name": "cloud_RoleName",
+}
+
+//// TABLE DEFINITION
+
+func tableAzureApplicationInsightAvailabilityResult(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_application_insight_availability_result",
+		Description: "Azure Application Insight Availability Result",
+		List: &plugin.ListConfig{
+			Hydrate:    listApplicationInsightAvailabilityResults,
+			KeyColumns: applicationInsightTelemetryKeyColumns(applicationInsightAvailabilityResultFilterColumns),
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "components/query/read",
+			},
+		},
+		Columns: azureColumns(
+			applicationInsightTelemetryColumns([]*plugin.Column{
+				{
+					Name:        "id",
+					Description: "The unique ID of the availability test run.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.id"),
+				},
+				{
+					Name:        "name",
+					Description: "The name of the availability test.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.name"),
+				},
+				{
+					Name:        "location",
+					Description: "The location the availability test was run from.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.location"),
+				},
+				{
+					Name:        "success",
+					Description: "Indicates whether the availability test succeeded.",
+					Type:        proto.ColumnType_BOOL,
+					Transform:   transform.FromField("Row.success").Transform(telemetryBoolValue),
+				},
+				{
+					Name:        "message",
+					Description: "The message returned by the availability test, e.g. the failure reason.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.message"),
+				},
+				{
+					Name:        "duration",
+					Description: "The duration of the availability test, in milliseconds.",
+					Type:        proto.ColumnType_DOUBLE,
+					Transform:   transform.FromField("Row.duration"),
+				},
+				{
+					Name:        "size",
+					Description: "The size of the response received by the availability test, in bytes.",
+					Type:        proto.ColumnType_DOUBLE,
+					Transform:   transform.FromField("Row.size"),
+				},
+				{
+					Name:        "performance_bucket",
+					Description: "The duration range the availability test falls into, e.g. 250ms-500ms.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.performanceBucket"),
+				},
+			}),
+		),
+	}
+}
+
+//// LIST FUNCTION
+
+func listApplicationInsightAvailabilityResults(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return streamApplicationInsightTelemetry(ctx, d, "availabilityResults", applicationInsightAvailabilityResultFilterColumns)
+}
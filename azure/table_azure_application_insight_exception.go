@@ -0,0 +1,110 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Columns of the exceptions table that can be filtered in the KQL query
+var applicationInsightExceptionFilterColumns = map[string]string{
+	"problem_id":      "problemId",
+	"type":            "type",
+	"operation_name":  "operation_Name",
+	"cloud_role_name": "cloud_RoleName",
+}
+
+//// TABLE DEFINITION
+
+func tableAzureApplicationInsightException(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_application_insight_exception",
+		Description: "Azure Application Insight Exception",
+		List: &plugin.ListConfig{
+			Hydrate:    listApplicationInsightExceptions,
+			KeyColumns: applicationInsightTelemetryKeyColumns(applicationInsightExceptionFilterColumns),
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "components/query/read",
+			},
+		},
+		Columns: azureColumns(
+			applicationInsightTelemetryColumns([]*plugin.Column{
+				{
+					Name:        "problem_id",
+					Description: "The identifier of the place the exception was thrown in code, used to group similar exceptions.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.problemId"),
+				},
+				{
+					Name:        "type",
+					Description: "The type of the exception.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.type"),
+				},
+				{
+					Name:        "message",
+					Description: "The message of the exception.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.message"),
+				},
+				{
+					Name:        "outer_message",
+					Description: "The message of the outermost exception.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.outerMessage"),
+				},
+				{
+					Name:        "innermost_type",
+					Description: "The type of the innermost exception.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.innermostType"),
+				},
+				{
+					Name:        "innermost_message",
+					Description: "The message of the innermost exception.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.innermostMessage"),
+				},
+				{
+					Name:        "method",
+					Description: "The method the exception was thrown in.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.method"),
+				},
+				{
+					Name:        "assembly",
+					Description: "The assembly the exception was thrown in.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.assembly"),
+				},
+				{
+					Name:        "handled_at",
+					Description: "Where the exception was handled, e.g. UserCode or Platform.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.handledAt"),
+				},
+				{
+					Name:        "severity_level",
+					Description: "The severity level of the exception, from 0 (verbose) to 4 (critical).",
+					Type:        proto.ColumnType_INT,
+					Transform:   transform.FromField("Row.severityLevel"),
+				},
+				{
+					Name:        "details",
+					Description: "The details of the exception, including the parsed stack.",
+					Type:        proto.ColumnType_JSON,
+					Transform:   transform.FromField("Row.details").Transform(parseTelemetryDynamicValue),
+				},
+			}),
+		),
+	}
+}
+
+//// LIST FUNCTION
+
+func listApplicationInsightExceptions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return streamApplicationInsightTelemetry(ctx, d, "exceptions", applicationInsightExceptionFilterColumns)
+}
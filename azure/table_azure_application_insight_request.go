@@ -0,0 +1,92 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Columns of the requests table that can be filtered in the KQL query
+var applicationInsightRequestFilterColumns = map[string]string{
+	"name":            "name",
+	"operation_name":  "operation_Name",
+	"result_code":     "resultCode",
+	"cloud_role_name": "cloud_RoleName",
+}
+
+//// TABLE DEFINITION
+
+func tableAzureApplicationInsightRequest(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_application_insight_request",
+		Description: "Azure Application Insight Request",
+		List: &plugin.ListConfig{
+			Hydrate:    listApplicationInsightRequests,
+			KeyColumns: applicationInsightTelemetryKeyColumns(applicationInsightRequestFilterColumns),
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "components/query/read",
+			},
+		},
+		Columns: azureColumns(
+			applicationInsightTelemetryColumns([]*plugin.Column{
+				{
+					Name:        "id",
+					Description: "The unique ID of the request.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.id"),
+				},
+				{
+					Name:        "name",
+					Description: "The name of the request, e.g. GET /api/orders.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.name"),
+				},
+				{
+					Name:        "url",
+					Description: "The URL of the request.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.url"),
+				},
+				{
+					Name:        "source",
+					Description: "The source of the request, e.g. the instrumentation key of the caller.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.source"),
+				},
+				{
+					Name:        "success",
+					Description: "Indicates whether the request was handled successfully.",
+					Type:        proto.ColumnType_BOOL,
+					Transform:   transform.FromField("Row.success").Transform(telemetryBoolValue),
+				},
+				{
+					Name:        "result_code",
+					Description: "The result code of the request, e.g. the HTTP status code.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.resultCode"),
+				},
+				{
+					Name:        "duration",
+					Description: "The time taken to handle the request, in milliseconds.",
+					Type:        proto.ColumnType_DOUBLE,
+					Transform:   transform.FromField("Row.duration"),
+				},
+				{
+					Name:        "performance_bucket",
+					Description: "The duration range the request falls into, e.g. 250ms-500ms.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Row.performanceBucket"),
+				},
+			}),
+		),
+	}
+}
+
+//// LIST FUNCTION
+
+func listApplicationInsightRequests(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return streamApplicationInsightTelemetry(ctx, d, "requests", applicationInsightRequestFilterColumns)
+}
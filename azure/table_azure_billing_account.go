@@ -0,0 +1,220 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/billing/mgmt/2020-05-01-preview/billing"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// billingAccountExpand lists the related entities returned inline with each billing account
+const billingAccountExpand = "soldTo,billingProfiles,enrollmentDetails,departments,enrollmentAccounts"
+
+//// TABLE DEFINITION
+
+func tableAzureBillingAccount(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_billing_account",
+		Description: "Azure Billing Account",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("name"),
+			Hydrate:    getBillingAccount,
+			Tags: map[string]string{
+				"service": "Microsoft.Billing",
+				"action":  "billingAccounts/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "BillingAccountNotFound", "404"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listBillingAccounts,
+			Tags: map[string]string{
+				"service": "Microsoft.Billing",
+				"action":  "billingAccounts/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name (ID) of the billing account. Use it as billing_account_id in the cost tables.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the billing account. This is also the scope to query costs for the whole account.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "display_name",
+				Description: "The billing account name.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccountProperties.DisplayName"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "agreement_type",
+				Description: "The type of agreement, e.g. MicrosoftCustomerAgreement, EnterpriseAgreement or MicrosoftOnlineServicesProgram.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccountProperties.AgreementType"),
+			},
+			{
+				Name:        "account_type",
+				Description: "The type of customer, e.g. Enterprise, Individual or Partner.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccountProperties.AccountType"),
+			},
+			{
+				Name:        "account_status",
+				Description: "The current status of the billing account.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccountProperties.AccountStatus"),
+			},
+			{
+				Name:        "has_read_access",
+				Description: "Indicates whether the user has read access to the billing account.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("AccountProperties.HasReadAccess"),
+			},
+			{
+				Name:        "sold_to",
+				Description: "The address of the individual or organization that is responsible for the billing account.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("AccountProperties.SoldTo"),
+			},
+			{
+				Name:        "billing_profiles",
+				Description: "The billing profiles associated with the billing account.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("AccountProperties.BillingProfiles.Value"),
+			},
+			{
+				Name:        "enrollment_details",
+				Description: "The details about the associated legacy enrollment. Only available for Enterprise Agreement billing accounts.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("AccountProperties.EnrollmentDetails"),
+			},
+			{
+				Name:        "departments",
+				Description: "The departments associated to the enrollment.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("AccountProperties.Departments"),
+			},
+			{
+				Name:        "enrollment_accounts",
+				Description: "The accounts associated to the enrollment.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("AccountProperties.EnrollmentAccounts"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccountProperties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listBillingAccounts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_billing_account.listBillingAccounts", "session_error", err)
+		return nil, err
+	}
+
+	client := billing.NewAccountsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	result, err := client.List(ctx, billingAccountExpand)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_billing_account.listBillingAccounts", "api_error", err)
+		return nil, err
+	}
+
+	for _, account := range result.Values() {
+		d.StreamListItem(ctx, account)
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	for result.NotDone() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_billing_account.listBillingAccounts", "paginator_error", err)
+			return nil, err
+		}
+
+		for _, account := range result.Values() {
+			d.StreamListItem(ctx, account)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getBillingAccount(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	if name == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_billing_account.getBillingAccount", "session_error", err)
+		return nil, err
+	}
+
+	client := billing.NewAccountsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	op, err := client.Get(ctx, name, billingAccountExpand)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_billing_account.getBillingAccount", "api_error", err)
+		return nil, err
+	}
+
+	// In some cases the API does not return any notFound error
+	// instead it returns empty data
+	if op.ID != nil {
+		return op, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,288 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/billing/mgmt/2020-05-01-preview/billing"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// BillingProfileInfo pairs a billing profile with the name of its billing account
+type BillingProfileInfo struct {
+	BillingAccountID string
+	billing.Profile
+}
+
+//// TABLE DEFINITION
+
+func tableAzureBillingProfile(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_billing_profile",
+		Description: "Azure Billing Profile",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"billing_account_id", "name"}),
+			Hydrate:    getBillingProfile,
+			Tags: map[string]string{
+				"service": "Microsoft.Billing",
+				"action":  "billingAccounts/billingProfiles/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "BillingAccountNotFound", "404"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			ParentHydrate: listBillingAccounts,
+			Hydrate:       listBillingProfiles,
+			Tags: map[string]string{
+				"service": "Microsoft.Billing",
+				"action":  "billingAccounts/billingProfiles/read",
+			},
+			KeyColumns: plugin.OptionalColumns([]string{"billing_account_id"}),
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name (ID) of the billing profile. Use it as billing_profile_id in the cost tables.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the billing profile. This is also the scope to query costs for the profile.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "billing_account_id",
+				Description: "The name (ID) of the billing account the profile belongs to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BillingAccountID"),
+			},
+			{
+				Name:        "display_name",
+				Description: "The name of the billing profile.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.DisplayName"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The status of the billing profile.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.Status"),
+			},
+			{
+				Name:        "status_reason_code",
+				Description: "The reason for the specified billing profile status.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.StatusReasonCode"),
+			},
+			{
+				Name:        "currency",
+				Description: "The currency in which the charges for the billing profile are billed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.Currency"),
+			},
+			{
+				Name:        "invoice_day",
+				Description: "The day of the month when the invoice for the billing profile is generated.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ProfileProperties.InvoiceDay"),
+			},
+			{
+				Name:        "invoice_email_opt_in",
+				Description: "Indicates whether invoices are sent to the email address specified in the bill_to address.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ProfileProperties.InvoiceEmailOptIn"),
+			},
+			{
+				Name:        "spending_limit",
+				Description: "The billing profile spending limit, either On or Off.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.SpendingLimit"),
+			},
+			{
+				Name:        "billing_relationship_type",
+				Description: "Identifies which services and purchases are paid by the billing profile.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.BillingRelationshipType"),
+			},
+			{
+				Name:        "po_number",
+				Description: "The purchase order name that will appear on the invoices generated for the billing profile.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.PoNumber"),
+			},
+			{
+				Name:        "system_id",
+				Description: "The system generated unique identifier for the billing profile.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.SystemID"),
+			},
+			{
+				Name:        "has_read_access",
+				Description: "Indicates whether the user has read access to the billing profile.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ProfileProperties.HasReadAccess"),
+			},
+			{
+				Name:        "bill_to",
+				Description: "The billing address.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ProfileProperties.BillTo"),
+			},
+			{
+				Name:        "enabled_azure_plans",
+				Description: "The Azure plans enabled for the billing profile.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ProfileProperties.EnabledAzurePlans"),
+			},
+			{
+				Name:        "invoice_sections",
+				Description: "The invoice sections associated to the billing profile.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ProfileProperties.InvoiceSections.Value"),
+			},
+			{
+				Name:        "indirect_relationship_info",
+				Description: "Identifies the billing profile that is linked to another billing profile in indirect purchase motion.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ProfileProperties.IndirectRelationshipInfo"),
+			},
+			{
+				Name:        "target_clouds",
+				Description: "Identifies the cloud environments that are associated with the billing profile.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ProfileProperties.TargetClouds"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProfileProperties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listBillingProfiles(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	account := h.Item.(billing.Account)
+	if account.Name == nil {
+		return nil, nil
+	}
+	accountName := *account.Name
+
+	// Only list the profiles of the requested billing account
+	if d.EqualsQualString("billing_account_id") != "" && d.EqualsQualString("billing_account_id") != accountName {
+		return nil, nil
+	}
+
+	// Billing profiles only exist for Microsoft Customer Agreement and Microsoft Partner Agreement accounts,
+	// listing them for other accounts, e.g. Enterprise Agreement accounts, fails
+	if account.AccountProperties != nil && account.AccountProperties.AgreementType != "" {
+		agreementType := account.AccountProperties.AgreementType
+		if agreementType != billing.MicrosoftCustomerAgreement && agreementType != billing.MicrosoftPartnerAgreement {
+			return nil, nil
+		}
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_billing_profile.listBillingProfiles", "session_error", err)
+		return nil, err
+	}
+
+	client := billing.NewProfilesClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	result, err := client.ListByBillingAccount(ctx, accountName, "invoiceSections")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_billing_profile.listBillingProfiles", "api_error", err)
+		return nil, err
+	}
+
+	for _, profile := range result.Values() {
+		d.StreamListItem(ctx, &BillingProfileInfo{accountName, profile})
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	for result.NotDone() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_billing_profile.listBillingProfiles", "paginator_error", err)
+			return nil, err
+		}
+
+		for _, profile := range result.Values() {
+			d.StreamListItem(ctx, &BillingProfileInfo{accountName, profile})
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getBillingProfile(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	accountName := d.EqualsQualString("billing_account_id")
+	name := d.EqualsQualString("name")
+	if accountName == "" || name == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_billing_profile.getBillingProfile", "session_error", err)
+		return nil, err
+	}
+
+	client := billing.NewProfilesClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	op, err := client.Get(ctx, accountName, name, "invoiceSections")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_billing_profile.getBillingProfile", "api_error", err)
+		return nil, err
+	}
+
+	// In some cases the API does not return any notFound error
+	// instead it returns empty data
+	if op.ID != nil {
+		return &BillingProfileInfo{accountName, op}, nil
+	}
+
+	return nil, nil
+}
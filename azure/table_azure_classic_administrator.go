@@ -0,0 +1,134 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureClassicAdministrator(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_classic_administrator",
+		Description: "Azure Classic Administrator",
+		List: &plugin.ListConfig{
+			Hydrate: listClassicAdministrators,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "classicAdministrators/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the administrator.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the administrator.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "email_address",
+				Description: "The email address of the administrator.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.EmailAddress"),
+			},
+			{
+				Name:        "role",
+				Description: "The role of the administrator, e.g. ServiceAdministrator, AccountAdministrator or CoAdministrator. Multiple roles are separated by semicolons.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Role"),
+			},
+			{
+				Name:        "roles",
+				Description: "The roles of the administrator.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Role").Transform(splitClassicAdministratorRoles),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Authorization/classicAdministrators).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.EmailAddress", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listClassicAdministrators(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_classic_administrator.listClassicAdministrators", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armauthorization.NewClassicAdministratorsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_classic_administrator.listClassicAdministrators", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_classic_administrator.listClassicAdministrators", "api_error", err)
+			return nil, err
+		}
+
+		for _, administrator := range page.Value {
+			d.StreamListItem(ctx, administrator)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func splitClassicAdministratorRoles(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	role := types.SafeString(d.Value)
+	if role == "" {
+		return nil, nil
+	}
+
+	var roles []string
+	for _, r := range strings.Split(role, ";") {
+		if r = strings.TrimSpace(r); r != "" {
+			roles = append(roles, r)
+		}
+	}
+	return roles, nil
+}
@@ -0,0 +1,300 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/consumption/mgmt/consumption"
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/resources/mgmt/resources"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// ConsumptionBudgetInfo pairs a budget with the scope it was listed from
+type ConsumptionBudgetInfo struct {
+	Scope             string
+	ResourceGroup     *string
+	ManagementGroupID *string
+	consumption.Budget
+}
+
+//// TABLE DEFINITION
+
+func tableAzureConsumptionBudget(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_consumption_budget",
+		Description: "Azure Consumption Budget",
+		List: &plugin.ListConfig{
+			Hydrate: listConsumptionBudgets,
+			Tags: map[string]string{
+				"service": "Microsoft.Consumption",
+				"action":  "budgets/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:      "scope",
+					Operators: []string{"="},
+					Require:   plugin.Optional,
+				},
+				{
+					Name:      "resource_group",
+					Operators: []string{"="},
+					Require:   plugin.Optional,
+				},
+				{
+					Name:      "management_group_id",
+					Operators: []string{"="},
+					Require:   plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the budget.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the budget.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope",
+				Description: "The scope the budget is defined at. Matches the scope column of the cost tables, e.g. azure_cost_forecast_monthly.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "management_group_id",
+				Description: "The management group the budget is defined at, if the budget is scoped to a management group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ManagementGroupID"),
+			},
+			{
+				Name:        "etag",
+				Description: "The eTag of the resource, used to detect concurrent updates.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ETag"),
+			},
+			{
+				Name:        "category",
+				Description: "The category of the budget, e.g. Cost.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BudgetProperties.Category"),
+			},
+			{
+				Name:        "amount",
+				Description: "The total amount of cost to track with the budget.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("BudgetProperties.Amount").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "time_grain",
+				Description: "The time covered by the budget, e.g. Monthly, Quarterly or Annually. Tracking of the amount is reset based on the time grain.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BudgetProperties.TimeGrain"),
+			},
+			{
+				Name:        "start_date",
+				Description: "The start date of the budget.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("BudgetProperties.TimePeriod.StartDate").Transform(convertDateToTime),
+			},
+			{
+				Name:        "end_date",
+				Description: "The end date of the budget.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("BudgetProperties.TimePeriod.EndDate").Transform(convertDateToTime),
+			},
+			{
+				Name:        "current_spend_amount",
+				Description: "The total amount of cost spent in the current time grain.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("BudgetProperties.CurrentSpend.Amount").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "current_spend_unit",
+				Description: "The unit of measure of the current spend, e.g. the currency.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BudgetProperties.CurrentSpend.Unit"),
+			},
+			{
+				Name:        "forecast_spend_amount",
+				Description: "The forecasted cost for the current time grain.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("BudgetProperties.ForecastSpend.Amount").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "forecast_spend_unit",
+				Description: "The unit of measure of the forecast spend, e.g. the currency.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BudgetProperties.ForecastSpend.Unit"),
+			},
+			{
+				Name:        "percent_used",
+				Description: "The current spend as a percentage of the budget amount.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("BudgetProperties").Transform(consumptionBudgetPercentUsed),
+			},
+			{
+				Name:        "filter",
+				Description: "The dimensions and tags the budget is filtered on.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("BudgetProperties.Filter"),
+			},
+			{
+				Name:        "notifications",
+				Description: "The notifications of the budget keyed by name, with the threshold, operator, contact emails, roles and action groups of each.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("BudgetProperties.Notifications"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "resource_group",
+				Description: "The resource group the budget is defined at, if the budget is scoped to a resource group.",
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listConsumptionBudgets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_consumption_budget.listConsumptionBudgets", "session_error", err)
+		return nil, err
+	}
+	subscriptionID := session.SubscriptionID
+
+	client := consumption.NewBudgetsClientWithBaseURI(session.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	var scopes []ConsumptionBudgetInfo
+	scope := d.EqualsQualString("scope")
+	resourceGroup := d.EqualsQualString("resource_group")
+	managementGroupID := d.EqualsQualString("management_group_id")
+
+	switch {
+	case scope != "":
+		scopes = append(scopes, ConsumptionBudgetInfo{Scope: scope})
+	case managementGroupID != "":
+		scopes = append(scopes, ConsumptionBudgetInfo{
+			Scope:             "/providers/Microsoft.Management/managementGroups/" + managementGroupID,
+			ManagementGroupID: &managementGroupID,
+		})
+	case resourceGroup != "":
+		scopes = append(scopes, ConsumptionBudgetInfo{
+			Scope:         "/subscriptions/" + subscriptionID + "/resourceGroups/" + resourceGroup,
+			ResourceGroup: &resourceGroup,
+		})
+	default:
+		// Budgets are only returned for the exact scope they are defined at, so the budgets
+		// of the subscription and of each of its resource groups are listed
+		scopes = append(scopes, ConsumptionBudgetInfo{Scope: "/subscriptions/" + subscriptionID})
+
+		groupsClient := resources.NewGroupsClientWithBaseURI(session.ResourceManagerEndpoint, subscriptionID)
+		groupsClient.Authorizer = session.Authorizer
+		ApplyRetryRules(ctx, &groupsClient, d.Connection)
+
+		groups, err := groupsClient.ListComplete(ctx, "", nil)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_consumption_budget.listConsumptionBudgets", "resource_group_api_error", err)
+			return nil, err
+		}
+		for groups.NotDone() {
+			if name := groups.Value().Name; name != nil {
+				scopes = append(scopes, ConsumptionBudgetInfo{
+					Scope:         "/subscriptions/" + subscriptionID + "/resourceGroups/" + *name,
+					ResourceGroup: name,
+				})
+			}
+			if err := groups.NextWithContext(ctx); err != nil {
+				plugin.Logger(ctx).Error("azure_consumption_budget.listConsumptionBudgets", "resource_group_paging_error", err)
+				return nil, err
+			}
+		}
+	}
+
+	for _, budgetScope := range scopes {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		result, err := client.List(ctx, budgetScope.Scope)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_consumption_budget.listConsumptionBudgets", "api_error", err)
+			return nil, err
+		}
+
+		for {
+			for _, budget := range result.Values() {
+				row := budgetScope
+				row.Budget = budget
+				d.StreamListItem(ctx, &row)
+
+				// Check if context has been cancelled or if the limit has been hit (if specified)
+				// if there is a limit, it will return the number of rows required to reach this limit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+
+			if !result.NotDone() {
+				break
+			}
+
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			err = result.NextWithContext(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_consumption_budget.listConsumptionBudgets", "paging_error", err)
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// consumptionBudgetPercentUsed returns the current spend of a budget as a percentage of its amount
+func consumptionBudgetPercentUsed(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	properties, ok := d.Value.(*consumption.BudgetProperties)
+	if !ok || properties == nil || properties.Amount == nil || properties.CurrentSpend == nil || properties.CurrentSpend.Amount == nil {
+		return nil, nil
+	}
+
+	amount, _ := properties.Amount.Float64()
+	if amount == 0 {
+		return nil, nil
+	}
+	currentSpend, _ := properties.CurrentSpend.Amount.Float64()
+	return currentSpend / amount * 100, nil
+}
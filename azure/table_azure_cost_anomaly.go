@@ -0,0 +1,371 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	defaultCostAnomalyBaselineDays   = 30
+	defaultCostAnomalyEvaluationDays = 30
+	defaultCostAnomalyZScore         = 3.0
+)
+
+// costAnomalyDimensionTypes are the dimensions the daily cost can be grouped by to detect anomalies
+var costAnomalyDimensionTypes = []string{"ServiceName", "ResourceGroupName"}
+
+// CostAnomalyRow is a day on which the cost of a dimension deviates from its rolling baseline
+type CostAnomalyRow struct {
+	UsageDate           time.Time
+	PeriodStart         time.Time
+	PeriodEnd           time.Time
+	DimensionType       string
+	DimensionValue      string
+	ActualCost          float64
+	ExpectedCost        float64
+	StandardDeviation   float64
+	Deviation           float64
+	DeviationPercentage *float64
+	ZScore              *float64
+	Direction           string
+	BaselineDays        int
+	ZScoreThreshold     *float64
+	PercentageThreshold *float64
+	Currency            *string
+	Scope               *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureCostAnomaly(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_cost_anomaly",
+		Description: "Azure Cost Management - Daily cost anomalies by service or resource group",
+		List: &plugin.ListConfig{
+			KeyColumns: append(costManagementKeyColumns(),
+				&plugin.KeyColumn{
+					Name:      "dimension_type",
+					Require:   plugin.Optional,
+					Operators: []string{"="},
+				},
+				&plugin.KeyColumn{
+					Name:      "baseline_days",
+					Require:   plugin.Optional,
+					Operators: []string{"="},
+				},
+				&plugin.KeyColumn{
+					Name:      "z_score_threshold",
+					Require:   plugin.Optional,
+					Operators: []string{"="},
+				},
+				&plugin.KeyColumn{
+					Name:      "percentage_threshold",
+					Require:   plugin.Optional,
+					Operators: []string{"="},
+				},
+			),
+			Hydrate: listCostAnomalies,
+			Tags:    map[string]string{"service": "Microsoft.CostManagement", "action": "Query"},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "usage_date",
+				Description: "The date on which the cost deviates from the baseline.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "dimension_type",
+				Description: "The dimension the daily cost is grouped by, either ServiceName or ResourceGroupName. Defaults to ServiceName if not specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dimension_value",
+				Description: "The service name or resource group whose cost deviates from the baseline.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "actual_cost",
+				Description: "The pre-tax cost of the dimension on the usage date.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "expected_cost",
+				Description: "The mean daily cost of the dimension over the baseline days before the usage date.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "standard_deviation",
+				Description: "The standard deviation of the daily cost of the dimension over the baseline days before the usage date.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "deviation",
+				Description: "The difference between the actual and the expected cost.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "deviation_percentage",
+				Description: "The deviation as a percentage of the expected cost. Null if the expected cost is zero.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "z_score",
+				Description: "The number of standard deviations between the actual and the expected cost. Null if the cost did not vary over the baseline days.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "direction",
+				Description: "Whether the actual cost is above (Increase) or below (Decrease) the expected cost.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "baseline_days",
+				Description: "The number of days before the usage date the expected cost is calculated from. Defaults to 30 if not specified in the where clause.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "z_score_threshold",
+				Description: "The absolute z-score from which a day is reported. Defaults to 3 if neither z_score_threshold nor percentage_threshold is specified in the where clause. If both are specified, a day is reported when it exceeds either of them.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "percentage_threshold",
+				Description: "The absolute deviation percentage from which a day is reported. If z_score_threshold is specified as well, a day is reported when it exceeds either of them.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "currency",
+				Description: "Currency code for the returned cost values.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "period_start",
+				Description: "The start of the time period evaluated for anomalies, derived from the period_start and period_end quals.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "period_end",
+				Description: "The end of the time period evaluated for anomalies, derived from the period_start and period_end quals.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "scope",
+				Description: "The Azure scope for the cost query (e.g., subscription, resource group, etc.).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cost_type",
+				Description: "The cost type for the query. Valid values are 'ActualCost' and 'AmortizedCost'.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("cost_type"),
+			},
+			{
+				Name:        "billing_account_id",
+				Description: "The billing account to query costs for. Combine with billing_profile_id and invoice_section_id to narrow the scope.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("billing_account_id"),
+			},
+			{
+				Name:        "billing_profile_id",
+				Description: "The billing profile to query costs for. Requires billing_account_id.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("billing_profile_id"),
+			},
+			{
+				Name:        "invoice_section_id",
+				Description: "The invoice section to query costs for. Requires billing_account_id and billing_profile_id.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("invoice_section_id"),
+			},
+			{
+				Name:        "management_group_id",
+				Description: "The management group to query costs for.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("management_group_id"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostAnomalies(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	dimensionType := "ServiceName"
+	if value := d.EqualsQualString("dimension_type"); value != "" {
+		dimensionType = value
+	}
+	validDimensionType := false
+	for _, t := range costAnomalyDimensionTypes {
+		validDimensionType = validDimensionType || t == dimensionType
+	}
+	if !validDimensionType {
+		return nil, fmt.Errorf("invalid dimension_type '%s', must be one of ServiceName or ResourceGroupName", dimensionType)
+	}
+
+	baselineDays := defaultCostAnomalyBaselineDays
+	if d.EqualsQuals["baseline_days"] != nil {
+		baselineDays = int(d.EqualsQuals["baseline_days"].GetInt64Value())
+		if baselineDays < 2 {
+			return nil, fmt.Errorf("baseline_days must be at least 2")
+		}
+	}
+
+	var zScoreThreshold, percentageThreshold *float64
+	if d.EqualsQuals["z_score_threshold"] != nil {
+		zScoreThreshold = to.Ptr(d.EqualsQuals["z_score_threshold"].GetDoubleValue())
+	}
+	if d.EqualsQuals["percentage_threshold"] != nil {
+		percentageThreshold = to.Ptr(d.EqualsQuals["percentage_threshold"].GetDoubleValue())
+	}
+	if zScoreThreshold == nil && percentageThreshold == nil {
+		zScoreThreshold = to.Ptr(defaultCostAnomalyZScore)
+	}
+
+	queryDef, scope, err := buildCostQueryInput(ctx, d, "DAILY", []string{dimensionType})
+	if err != nil {
+		return nil, err
+	}
+
+	// Anomalies are always detected on the pre-tax cost, over the evaluated days and the baseline days before them
+	queryDef.Dataset.Aggregation = map[string]*armcostmanagement.QueryAggregation{
+		"PreTaxCost": {
+			Function: to.Ptr(armcostmanagement.FunctionTypeSum),
+			Name:     to.Ptr("PreTaxCost"),
+		},
+	}
+	periodStart, periodEnd := getCostAnomalyTimeRange(d)
+	queryDef.TimePeriod = &armcostmanagement.QueryTimePeriod{
+		From: to.Ptr(periodStart.Truncate(24*time.Hour).AddDate(0, 0, -baselineDays)),
+		To:   to.Ptr(periodEnd),
+	}
+
+	rowMap, err := getCostAndUsage(ctx, d, queryDef, scope, dimensionType)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_anomaly.listCostAnomalies", "api_error", err)
+		return nil, err
+	}
+
+	// Build the daily cost series of each dimension. Days without usage are not returned by the
+	// Query API, so they count as zero cost.
+	dailyCosts := map[string]map[string]float64{}
+	var currency, resolvedScope *string
+	for _, row := range rowMap {
+		if row.UsageDate == nil || row.PreTaxCostAmount == nil {
+			continue
+		}
+		dimensionValue := ""
+		if row.Dimension1 != nil {
+			dimensionValue = *row.Dimension1
+		}
+		if dailyCosts[dimensionValue] == nil {
+			dailyCosts[dimensionValue] = map[string]float64{}
+		}
+		dailyCosts[dimensionValue][row.UsageDate.Format("2006-01-02")] += *row.PreTaxCostAmount
+		currency, resolvedScope = row.Currency, row.Scope
+	}
+
+	for dimensionValue, costs := range dailyCosts {
+		for day := periodStart.Truncate(24 * time.Hour); !day.After(periodEnd); day = day.AddDate(0, 0, 1) {
+			baseline := make([]float64, 0, baselineDays)
+			for i := baselineDays; i > 0; i-- {
+				baseline = append(baseline, costs[day.AddDate(0, 0, -i).Format("2006-01-02")])
+			}
+			mean, stddev := meanAndStandardDeviation(baseline)
+			actual := costs[day.Format("2006-01-02")]
+
+			row := CostAnomalyRow{
+				UsageDate:           day,
+				PeriodStart:         periodStart,
+				PeriodEnd:           periodEnd,
+				DimensionType:       dimensionType,
+				DimensionValue:      dimensionValue,
+				ActualCost:          actual,
+				ExpectedCost:        mean,
+				StandardDeviation:   stddev,
+				Deviation:           actual - mean,
+				Direction:           "Increase",
+				BaselineDays:        baselineDays,
+				ZScoreThreshold:     zScoreThreshold,
+				PercentageThreshold: percentageThreshold,
+				Currency:            currency,
+				Scope:               resolvedScope,
+			}
+			if row.Deviation < 0 {
+				row.Direction = "Decrease"
+			}
+			if mean != 0 {
+				row.DeviationPercentage = to.Ptr(row.Deviation / mean * 100)
+			}
+			if stddev != 0 {
+				row.ZScore = to.Ptr(row.Deviation / stddev)
+			}
+
+			if !isCostAnomaly(row) {
+				continue
+			}
+			d.StreamListItem(ctx, row)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// getCostAnomalyTimeRange returns the days to evaluate for anomalies. Defaults to the last 30 days through
+// yesterday, or derives the days from the period_start and period_end quals like the other cost tables.
+func getCostAnomalyTimeRange(d *plugin.QueryData) (time.Time, time.Time) {
+	if d.Quals["period_start"] == nil && d.Quals["period_end"] == nil {
+		end := time.Now().UTC().AddDate(0, 0, -1).Truncate(24 * time.Hour)
+		return end.AddDate(0, 0, 1-defaultCostAnomalyEvaluationDays), end
+	}
+	return getPeriodTimeRange(d, "DAILY")
+}
+
+// meanAndStandardDeviation returns the mean and the population standard deviation of the values
+func meanAndStandardDeviation(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+// isCostAnomaly reports whether the deviation of a day exceeds any of the thresholds that are set.
+// A cost that did not vary over the baseline exceeds any z-score as soon as it changes, and
+// a cost that was zero over the baseline exceeds any percentage as soon as it is spent.
+func isCostAnomaly(row CostAnomalyRow) bool {
+	if row.Deviation == 0 {
+		return false
+	}
+	if row.ZScoreThreshold != nil && (row.ZScore == nil || math.Abs(*row.ZScore) >= *row.ZScoreThreshold) {
+		return true
+	}
+	if row.PercentageThreshold != nil && (row.DeviationPercentage == nil || math.Abs(*row.DeviationPercentage) >= *row.PercentageThreshold) {
+		return true
+	}
+	return false
+}
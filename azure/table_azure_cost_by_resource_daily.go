@@ -0,0 +1,155 @@
+package azure
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// CostByResourceRow is the cost of a meter of a resource, with the properties of the resource
+type CostByResourceRow struct {
+	CostManagementRow
+	ResourceID       *string
+	ResourceType     *string
+	ResourceGroup    *string
+	ResourceLocation *string
+	Meter            *string
+}
+
+func tableAzureCostByResourceDaily(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_cost_by_resource_daily",
+		Description: "Azure Cost Management - Daily cost by resource and meter",
+		List: &plugin.ListConfig{
+			KeyColumns: append(costManagementKeyColumns(),
+				&plugin.KeyColumn{
+					Name:      "resource_id",
+					Require:   plugin.Optional,
+					Operators: []string{"=", "<>"},
+				},
+				&plugin.KeyColumn{
+					Name:      "resource_group",
+					Require:   plugin.Optional,
+					Operators: []string{"=", "<>"},
+				},
+				&plugin.KeyColumn{
+					Name:      "resource_location",
+					Require:   plugin.Optional,
+					Operators: []string{"=", "<>"},
+				},
+				&plugin.KeyColumn{
+					Name:      "meter",
+					Require:   plugin.Optional,
+					Operators: []string{"=", "<>"},
+				},
+			),
+			Hydrate: listCostByResourceDaily,
+			Tags:    map[string]string{"service": "Microsoft.CostManagement", "action": "Query"},
+		},
+		Columns: azureColumns(
+			costManagementColumns([]*plugin.Column{
+				{
+					Name:        "resource_id",
+					Description: "The ID of the Azure resource. Cost Management returns resource IDs in lower case, unless the ID is given in the where clause.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("ResourceID"),
+				},
+				{
+					Name:        "resource_type",
+					Description: "The type of the Azure resource (e.g., microsoft.compute/virtualmachines), taken from the resource ID.",
+					Type:        proto.ColumnType_STRING,
+				},
+				{
+					Name:        "resource_group",
+					Description: "The name of the resource group of the Azure resource, taken from the resource ID.",
+					Type:        proto.ColumnType_STRING,
+				},
+				{
+					Name:        "resource_location",
+					Description: "The location of the Azure resource (e.g., eastus). Selecting or filtering on this column takes a second query grouped by resource and location.",
+					Type:        proto.ColumnType_STRING,
+				},
+				{
+					Name:        "meter",
+					Description: "The name of the meter the cost was charged on (e.g., D2s v3, P10 LRS Disk).",
+					Type:        proto.ColumnType_STRING,
+				},
+			}),
+		),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostByResourceDaily(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// The Query API allows at most 2 group by clauses. The resource group and type are taken from the resource ID,
+	// and the location, if needed, from a second query grouped by resource and location.
+	groupingNames := []string{"ResourceId", "Meter"}
+	queryDef, scope, err := buildCostQueryInput(ctx, d, "DAILY", groupingNames)
+	if err != nil {
+		return nil, err
+	}
+
+	rowMap, err := getCostAndUsage(ctx, d, queryDef, scope, groupingNames...)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_by_resource_daily.listCostByResourceDaily", "api_error", err)
+		return nil, err
+	}
+
+	locations := map[string]string{}
+	if slices.Contains(d.QueryContext.Columns, "resource_location") || d.Quals["resource_location"] != nil {
+		locationGroupingNames := []string{"ResourceId", "ResourceLocation"}
+		locationQueryDef, _, err := buildCostQueryInput(ctx, d, "DAILY", locationGroupingNames)
+		if err != nil {
+			return nil, err
+		}
+
+		locationRowMap, err := getCostAndUsage(ctx, d, locationQueryDef, scope, locationGroupingNames...)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_cost_by_resource_daily.listCostByResourceDaily", "api_error", err)
+			return nil, err
+		}
+		for _, row := range locationRowMap {
+			if row.Dimension1 != nil && row.Dimension2 != nil {
+				locations[strings.ToLower(*row.Dimension1)] = *row.Dimension2
+			}
+		}
+	}
+
+	for _, row := range rowMap {
+		if queryDef.TimePeriod != nil {
+			row.PeriodStart = queryDef.TimePeriod.From
+			row.PeriodEnd = queryDef.TimePeriod.To
+		}
+
+		item := CostByResourceRow{
+			CostManagementRow: *row,
+			Meter:             row.Dimension2,
+		}
+		if row.Dimension1 != nil {
+			item.ResourceID = to.Ptr(getQualValueCasing(d, "resource_id", *row.Dimension1))
+			if resourceID, err := arm.ParseResourceID(*row.Dimension1); err == nil {
+				if resourceID.ResourceGroupName != "" {
+					item.ResourceGroup = to.Ptr(getQualValueCasing(d, "resource_group", resourceID.ResourceGroupName))
+				}
+				item.ResourceType = to.Ptr(strings.ToLower(resourceID.ResourceType.String()))
+			}
+			if v := locations[strings.ToLower(*row.Dimension1)]; v != "" {
+				item.ResourceLocation = to.Ptr(getQualValueCasing(d, "resource_location", v))
+			}
+		}
+		d.StreamListItem(ctx, item)
+
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -15,8 +15,9 @@ func tableAzureCostByResourceGroupDaily(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			KeyColumns: append(costManagementKeyColumns(),
 				&plugin.KeyColumn{
-					Name:    "resource_group",
-					Require: plugin.Optional,
+					Name:      "resource_group",
+					Require:   plugin.Optional,
+					Operators: []string{"=", "<>"},
 				},
 			),
 			Hydrate: listCostByResourceGroupDaily,
@@ -15,8 +15,9 @@ func tableAzureCostByServiceDaily(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			KeyColumns: append(costManagementKeyColumns(),
 				&plugin.KeyColumn{
-					Name:    "service_name",
-					Require: plugin.Optional,
+					Name:      "service_name",
+					Require:   plugin.Optional,
+					Operators: []string{"=", "<>"},
 				},
 			),
 			Hydrate: listCostByServiceDaily,
@@ -0,0 +1,34 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureCostByTagMonthly(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_cost_by_tag_monthly",
+		Description: "Azure Cost Management - Cost by Tag (Monthly)",
+		List: &plugin.ListConfig{
+			Hydrate:    listCostByTagMonthly,
+			Tags:       map[string]string{"service": "Microsoft.CostManagement", "action": "Query"},
+			KeyColumns: costByTagKeyColumns(),
+		},
+		Columns: azureColumns(
+			costManagementColumns(costByTagColumns()),
+		),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostByTagMonthly(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	queryDef, scope, err := buildCostByTagQueryInput(ctx, d, "MONTHLY")
+	if err != nil {
+		return nil, err
+	}
+	return streamCostAndUsage(ctx, d, queryDef, scope, "TagValue")
+}
@@ -0,0 +1,314 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// CostManagementExportInfo pairs an export with the scope it was listed from and its most recent run
+type CostManagementExportInfo struct {
+	Scope   string
+	LastRun *armcostmanagement.ExportRunProperties
+	armcostmanagement.Export
+}
+
+//// TABLE DEFINITION
+
+func tableAzureCostManagementExport(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_cost_management_export",
+		Description: "Azure Cost Management Export",
+		List: &plugin.ListConfig{
+			Hydrate: listCostManagementExports,
+			Tags: map[string]string{
+				"service": "Microsoft.CostManagement",
+				"action":  "exports/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the export.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the export.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope",
+				Description: "The scope the export is defined at. Defaults to the current subscription if not specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "etag",
+				Description: "The eTag of the resource, used to detect concurrent updates.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ETag"),
+			},
+			{
+				Name:        "schedule_status",
+				Description: "The status of the export's schedule. If 'Inactive', the export's schedule is paused.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Schedule.Status"),
+			},
+			{
+				Name:        "recurrence",
+				Description: "The schedule recurrence, e.g. Daily, Weekly, Monthly or Annually.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Schedule.Recurrence"),
+			},
+			{
+				Name:        "recurrence_period_from",
+				Description: "The start date of the recurrence.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.Schedule.RecurrencePeriod.From"),
+			},
+			{
+				Name:        "recurrence_period_to",
+				Description: "The end date of the recurrence.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.Schedule.RecurrencePeriod.To"),
+			},
+			{
+				Name:        "next_run_time_estimate",
+				Description: "If the export has an active schedule, the estimated time of the next run.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.NextRunTimeEstimate"),
+			},
+			{
+				Name:        "format",
+				Description: "The format of the export being delivered.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Format"),
+			},
+			{
+				Name:        "partition_data",
+				Description: "Indicates whether the data is partitioned into multiple files for large exports.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.PartitionData"),
+			},
+			{
+				Name:        "definition_type",
+				Description: "The type of the export, e.g. Usage, ActualCost or AmortizedCost.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Definition.Type"),
+			},
+			{
+				Name:        "timeframe",
+				Description: "The time frame for pulling data for the export.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Definition.Timeframe"),
+			},
+			{
+				Name:        "time_period",
+				Description: "The custom date range of the export, if the time frame is Custom.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Definition.TimePeriod"),
+			},
+			{
+				Name:        "data_set",
+				Description: "The export data set configuration, including the granularity and columns.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Definition.DataSet"),
+			},
+			{
+				Name:        "destination_resource_id",
+				Description: "The resource ID of the storage account the export is delivered to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DeliveryInfo.Destination.ResourceID"),
+			},
+			{
+				Name:        "destination_storage_account",
+				Description: "The name of the storage account the export is delivered to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DeliveryInfo.Destination").Transform(costManagementExportStorageAccount),
+			},
+			{
+				Name:        "destination_container",
+				Description: "The name of the container the export is uploaded to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DeliveryInfo.Destination.Container"),
+			},
+			{
+				Name:        "destination_root_folder_path",
+				Description: "The directory the export is uploaded to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DeliveryInfo.Destination.RootFolderPath"),
+			},
+			{
+				Name:        "last_run_status",
+				Description: "The status of the most recent run of the export, e.g. Completed, Failed or InProgress.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LastRun.Status"),
+			},
+			{
+				Name:        "last_run_execution_type",
+				Description: "The type of the most recent run, either OnDemand or Scheduled.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LastRun.ExecutionType"),
+			},
+			{
+				Name:        "last_run_submitted_time",
+				Description: "The time the most recent run was submitted.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("LastRun.SubmittedTime"),
+			},
+			{
+				Name:        "last_run_processing_end_time",
+				Description: "The time the most recent run finished processing.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("LastRun.ProcessingEndTime"),
+			},
+			{
+				Name:        "last_run_file_name",
+				Description: "The name of the file the most recent run delivered.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LastRun.FileName"),
+			},
+			{
+				Name:        "last_run_error",
+				Description: "The details of any error of the most recent run.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("LastRun.Error"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostManagementExports(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	scope, err := getCostManagementScope(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_management_export.listCostManagementExports", "scope_error", err)
+		return nil, err
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_management_export.listCostManagementExports", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armcostmanagement.NewExportsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_management_export.listCostManagementExports", "client_error", err)
+		return nil, err
+	}
+
+	// Wait for rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	// Expanding runHistory returns the most recent run of each export
+	result, err := client.List(ctx, scope, &armcostmanagement.ExportsClientListOptions{Expand: to.Ptr("runHistory")})
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_management_export.listCostManagementExports", "api_error", err)
+		return nil, err
+	}
+
+	for _, export := range result.Value {
+		if export == nil {
+			continue
+		}
+		d.StreamListItem(ctx, &CostManagementExportInfo{
+			Scope:   scope,
+			LastRun: getCostManagementExportLastRun(export),
+			Export:  *export,
+		})
+
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// getCostManagementScope returns the scope from the quals, defaulting to the current subscription
+func getCostManagementScope(ctx context.Context, d *plugin.QueryData) (string, error) {
+	scope, err := getCostScopeFromQuals(d)
+	if err != nil || scope != "" {
+		return scope, err
+	}
+
+	subscriptionID, err := getSubscriptionID(ctx, d, nil)
+	if err != nil {
+		return "", err
+	}
+	return "/subscriptions/" + subscriptionID.(string), nil
+}
+
+// getCostManagementExportLastRun returns the most recently submitted run in the run history of an export
+func getCostManagementExportLastRun(export *armcostmanagement.Export) *armcostmanagement.ExportRunProperties {
+	if export.Properties == nil || export.Properties.RunHistory == nil {
+		return nil
+	}
+
+	var lastRun *armcostmanagement.ExportRunProperties
+	for _, run := range export.Properties.RunHistory.Value {
+		if run == nil || run.Properties == nil {
+			continue
+		}
+		if lastRun == nil || lastRun.SubmittedTime == nil || (run.Properties.SubmittedTime != nil && run.Properties.SubmittedTime.After(*lastRun.SubmittedTime)) {
+			lastRun = run.Properties
+		}
+	}
+	return lastRun
+}
+
+//// TRANSFORM FUNCTIONS
+
+// costManagementExportStorageAccount returns the name of the destination storage account, which is only
+// returned for exports delivered using a SAS token, so it falls back to the last segment of the resource ID
+func costManagementExportStorageAccount(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	destination, ok := d.Value.(*armcostmanagement.ExportDeliveryDestination)
+	if !ok || destination == nil {
+		return nil, nil
+	}
+	if destination.StorageAccount != nil && *destination.StorageAccount != "" {
+		return *destination.StorageAccount, nil
+	}
+	if destination.ResourceID != nil && *destination.ResourceID != "" {
+		parts := strings.Split(strings.TrimSuffix(*destination.ResourceID, "/"), "/")
+		return parts[len(parts)-1], nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,236 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// CostManagementViewInfo pairs a view with the scope it was listed from, which is nil for private views
+type CostManagementViewInfo struct {
+	Scope *string
+	armcostmanagement.View
+}
+
+//// TABLE DEFINITION
+
+func tableAzureCostManagementView(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_cost_management_view",
+		Description: "Azure Cost Management View",
+		List: &plugin.ListConfig{
+			Hydrate: listCostManagementViews,
+			Tags: map[string]string{
+				"service": "Microsoft.CostManagement",
+				"action":  "views/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the view.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the view.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "display_name",
+				Description: "The user input name of the view.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope",
+				Description: "The scope the view is shared at. Null for private views. If not specified in the where clause, the private views of the caller and the views shared at the current subscription are listed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "etag",
+				Description: "The eTag of the resource, used to detect concurrent updates.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ETag"),
+			},
+			{
+				Name:        "chart",
+				Description: "The chart type of the main view in Cost Analysis.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Chart"),
+			},
+			{
+				Name:        "accumulated",
+				Description: "Indicates whether costs are shown accumulated over time.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Accumulated"),
+			},
+			{
+				Name:        "metric",
+				Description: "The metric to use when displaying costs.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Metric"),
+			},
+			{
+				Name:        "date_range",
+				Description: "The date range of the view.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DateRange"),
+			},
+			{
+				Name:        "currency",
+				Description: "The currency of the view.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Currency"),
+			},
+			{
+				Name:        "created_on",
+				Description: "The date the view was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.CreatedOn"),
+			},
+			{
+				Name:        "modified_on",
+				Description: "The date the view was last modified.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.ModifiedOn"),
+			},
+			{
+				Name:        "query_type",
+				Description: "The type of the report, e.g. Usage.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Query.Type"),
+			},
+			{
+				Name:        "timeframe",
+				Description: "The time frame of the report.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Query.Timeframe"),
+			},
+			{
+				Name:        "query",
+				Description: "The report definition of the view, including the data set, grouping and filters.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Query"),
+			},
+			{
+				Name:        "kpis",
+				Description: "The KPIs shown in the view.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Kpis"),
+			},
+			{
+				Name:        "pivots",
+				Description: "The pivots shown in the view.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Pivots"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostManagementViews(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_management_view.listCostManagementViews", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armcostmanagement.NewViewsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_management_view.listCostManagementViews", "client_error", err)
+		return nil, err
+	}
+
+	// Private views are only listed when no scope is requested
+	if d.EqualsQualString("scope") == "" {
+		pager := client.NewListPager(nil)
+		for pager.More() {
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_cost_management_view.listCostManagementViews", "api_error", err)
+				return nil, err
+			}
+
+			for _, view := range page.Value {
+				if view == nil {
+					continue
+				}
+				d.StreamListItem(ctx, &CostManagementViewInfo{View: *view})
+
+				// Check if context has been cancelled or if the limit has been hit (if specified)
+				// if there is a limit, it will return the number of rows required to reach this limit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	scope, err := getCostManagementScope(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_cost_management_view.listCostManagementViews", "scope_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListByScopePager(scope, nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_cost_management_view.listCostManagementViews", "api_error", err)
+			return nil, err
+		}
+
+		for _, view := range page.Value {
+			if view == nil {
+				continue
+			}
+			d.StreamListItem(ctx, &CostManagementViewInfo{Scope: &scope, View: *view})
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement/v2"
@@ -34,6 +33,16 @@ func tableAzureCostUsage(_ context.Context) *plugin.Table {
 				Name:    "dimension_types",
 				Require: plugin.AnyOf,
 			},
+			{
+				Name:      "dimension_1",
+				Require:   plugin.Optional,
+				Operators: []string{"=", "<>"},
+			},
+			{
+				Name:      "dimension_2",
+				Require:   plugin.Optional,
+				Operators: []string{"=", "<>"},
+			},
 		}, keyColumns...,
 	)
 
@@ -68,7 +77,7 @@ func tableAzureCostUsage(_ context.Context) *plugin.Table {
 				// Quals columns - to filter the lookups
 				{
 					Name:        "granularity",
-					Description: "The Azure cost granularity. Valid values are Daily or Monthly.",
+					Description: "The Azure cost granularity. Valid values are Hourly, Daily or Monthly.",
 					Type:        proto.ColumnType_STRING,
 					Hydrate:     hydrateCostUsageQuals,
 				},
@@ -112,7 +121,10 @@ func buildCostUsageInputFromQuals(ctx context.Context, d *plugin.QueryData) (arm
 	granularity := strings.ToUpper(d.EqualsQuals["granularity"].GetStringValue())
 
 	// Get scope from quals, default to placeholder if not provided
-	scope := d.EqualsQualString("scope")
+	scope, err := getCostScopeFromQuals(d)
+	if err != nil {
+		return armcostmanagement.QueryDefinition{}, "", "", "", []string{}, err
+	}
 	if scope == "" {
 		scope = "/subscriptions/placeholder" // Will be resolved in streamCostAndUsage
 	}
@@ -128,38 +140,7 @@ func buildCostUsageInputFromQuals(ctx context.Context, d *plugin.QueryData) (arm
 	timePeriod := &armcostmanagement.QueryTimePeriod{}
 
 	// Get time range from period_start/period_end quals
-	startTime, endTime := getPeriodTimeRange(d, granularity)
-
-	// Set default time range if no quals provided
-	if startTime == "" || endTime == "" {
-		var defaultStart, defaultEnd time.Time
-		switch granularity {
-		case "MONTHLY", "DAILY":
-			// Default: 1 year back
-			defaultEnd = time.Now()
-			defaultStart = defaultEnd.AddDate(0, -11, -30)
-		default:
-			// Default: Last 30 days
-			defaultEnd = time.Now()
-			defaultStart = defaultEnd.AddDate(0, 0, -30)
-		}
-
-		if startTime == "" {
-			startTime = defaultStart.Format("2006-01-02")
-		}
-		if endTime == "" {
-			endTime = defaultEnd.Format("2006-01-02")
-		}
-	}
-
-	startDate, err := time.Parse("2006-01-02", startTime)
-	if err != nil {
-		return armcostmanagement.QueryDefinition{}, "", "", "", []string{}, fmt.Errorf("failed to parse start date: %v", err)
-	}
-	endDate, err := time.Parse("2006-01-02", endTime)
-	if err != nil {
-		return armcostmanagement.QueryDefinition{}, "", "", "", []string{}, fmt.Errorf("failed to parse end date: %v", err)
-	}
+	startDate, endDate := getPeriodTimeRange(d, granularity)
 
 	timePeriod.From = to.Ptr(startDate)
 	timePeriod.To = to.Ptr(endDate)
@@ -200,6 +181,20 @@ func buildCostUsageInputFromQuals(ctx context.Context, d *plugin.QueryData) (arm
 		}
 	}
 
+	// Build filter expressions from the quals on dimension_1 and dimension_2, which hold
+	// the values of the first and second grouping dimension respectively
+	dimensionColumns := map[string]string{
+		"dimension_1": dim1,
+		"dimension_2": dim2,
+	}
+	if len(dimensions) > 0 {
+		dimensionColumns["dimension_1"] = dimensions[0]
+		if len(dimensions) > 1 {
+			dimensionColumns["dimension_2"] = dimensions[1]
+		}
+	}
+	filter := buildFilterExpression(d, dimensionColumns)
+
 	// Get dynamic columns based on query context
 	requestColumns := getColumnsFromQueryContext(d.QueryContext)
 
@@ -245,6 +240,11 @@ func buildCostUsageInputFromQuals(ctx context.Context, d *plugin.QueryData) (arm
 		}
 	}
 
+	// Add filter if specified
+	if filter != nil {
+		dataset.Filter = filter
+	}
+
 	// Create QueryDefinition
 	queryDef := armcostmanagement.QueryDefinition{
 		Type:      to.Ptr(getCostTypeFromString(costType)),
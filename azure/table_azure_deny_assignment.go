@@ -0,0 +1,336 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/Azure/azure-sdk-for-go/services/solutions/mgmt/2021-07-01/managedapplications"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// DenyAssignmentInfo is a deny assignment, with the scope reported with the casing used in the quals
+type DenyAssignmentInfo struct {
+	armauthorization.DenyAssignment
+	Scope *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureDenyAssignment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_deny_assignment",
+		Description: "Azure Deny Assignment",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getDenyAssignment,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "denyAssignments/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"DenyAssignmentNotFound", "ResourceNotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listDenyAssignments,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "denyAssignments/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				// The scope may be given with or without the leading "/" (forward slash), in any casing
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the deny assignment resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the deny assignment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "deny_assignment_name",
+				Description: "The display name of the deny assignment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DenyAssignmentName"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the deny assignment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "scope",
+				Description: "The scope of the deny assignment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Scope"),
+			},
+			{
+				Name:        "is_system_protected",
+				Description: "Specifies whether the deny assignment is system protected, e.g. created by a managed application or a blueprint.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.IsSystemProtected"),
+			},
+			{
+				Name:        "do_not_apply_to_child_scopes",
+				Description: "Specifies whether the deny assignment applies to the scope only, and not to its child scopes.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.DoNotApplyToChildScopes"),
+			},
+			{
+				Name:        "managed_application_id",
+				Description: "The ID of the managed application that protects its managed resource group with the deny assignment.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDenyAssignmentManagedApplication,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Authorization/denyAssignments).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principals",
+				Description: "The principals denied access. A principal with ID 00000000-0000-0000-0000-000000000000 and type SystemDefined stands for all principals.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Principals"),
+			},
+			{
+				Name:        "exclude_principals",
+				Description: "The principals excluded from the deny assignment.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ExcludePrincipals"),
+			},
+			{
+				Name:        "permissions",
+				Description: "The permissions denied, i.e. the actions, data actions, not actions and not data actions of the deny assignment.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Permissions"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DenyAssignmentName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listDenyAssignments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_deny_assignment.listDenyAssignments", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armauthorization.NewDenyAssignmentsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_deny_assignment.listDenyAssignments", "client_error", err)
+		return nil, err
+	}
+
+	scope := d.EqualsQualString("scope")
+	if scope != "" {
+		return nil, listDenyAssignmentsByScope(ctx, d, client, scope)
+	}
+
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_deny_assignment.listDenyAssignments", "api_error", err)
+			return nil, err
+		}
+
+		for _, denyAssignment := range page.Value {
+			d.StreamListItem(ctx, denyAssignmentInfo(denyAssignment))
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// listDenyAssignmentsByScope retrieves the deny assignments defined at a specific scope
+func listDenyAssignmentsByScope(ctx context.Context, d *plugin.QueryData, client *armauthorization.DenyAssignmentsClient, scope string) error {
+	// atScope() returns the deny assignments at or above the scope
+	filter := "atScope()"
+	pager := client.NewListForScopePager(strings.TrimPrefix(normalizeRoleAssignmentScope(scope), "/"), &armauthorization.DenyAssignmentsClientListForScopeOptions{Filter: &filter})
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_deny_assignment.listDenyAssignmentsByScope", "api_error", err)
+			return err
+		}
+
+		for _, denyAssignment := range page.Value {
+			item := denyAssignmentInfo(denyAssignment)
+			if item.Scope == nil || !isSameRoleAssignmentScope(*item.Scope, scope) {
+				continue
+			}
+			// Report the scope as queried, so that the assignment is not dropped for differing in casing or in the leading slash
+			item.Scope = &scope
+
+			d.StreamListItem(ctx, item)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDenyAssignment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+	if id == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_deny_assignment.getDenyAssignment", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armauthorization.NewDenyAssignmentsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_deny_assignment.getDenyAssignment", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.GetByID(ctx, strings.TrimPrefix(id, "/"), nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_deny_assignment.getDenyAssignment", "api_error", err)
+		return nil, err
+	}
+
+	return denyAssignmentInfo(&op.DenyAssignment), nil
+}
+
+func getDenyAssignmentManagedApplication(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	denyAssignment := h.Item.(DenyAssignmentInfo)
+	if denyAssignment.Properties == nil || denyAssignment.Properties.Scope == nil {
+		return nil, nil
+	}
+
+	applications, err := getManagedApplicationsByResourceGroup(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	// Managed applications deny access to their managed resource group
+	scope := strings.ToLower(normalizeRoleAssignmentScope(*denyAssignment.Properties.Scope))
+	for resourceGroupID, applicationID := range applications.(map[string]string) {
+		if scope == resourceGroupID || strings.HasPrefix(scope, resourceGroupID+"/") {
+			return applicationID, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// if the caching is required other than per connection, build a cache key for the call and use it in Memoize.
+var getManagedApplicationsByResourceGroupMemoized = plugin.HydrateFunc(getManagedApplicationsByResourceGroupUncached).Memoize(memoize.WithCacheKeyFunction(getManagedApplicationsByResourceGroupCacheKey))
+
+// declare a wrapper hydrate function to call the memoized function
+// - this is required when a memoized function is used for a column definition
+func getManagedApplicationsByResourceGroup(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getManagedApplicationsByResourceGroupMemoized(ctx, d, h)
+}
+
+// Build a cache key for the call to getManagedApplicationsByResourceGroup.
+func getManagedApplicationsByResourceGroupCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := "getManagedApplicationsByResourceGroup"
+	return key, nil
+}
+
+// getManagedApplicationsByResourceGroupUncached lists the managed applications of the subscription, returning
+// the application IDs keyed by lower case managed resource group ID
+func getManagedApplicationsByResourceGroupUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("getManagedApplicationsByResourceGroupUncached", "session_error", err)
+		return nil, err
+	}
+
+	client := managedapplications.NewApplicationsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	result, err := client.ListBySubscription(ctx)
+	if err != nil {
+		plugin.Logger(ctx).Error("getManagedApplicationsByResourceGroupUncached", "api_error", err)
+		return nil, err
+	}
+
+	applications := make(map[string]string)
+	for {
+		for _, application := range result.Values() {
+			if application.ID != nil && application.ApplicationProperties != nil && application.ManagedResourceGroupID != nil {
+				applications[strings.ToLower(normalizeRoleAssignmentScope(*application.ManagedResourceGroupID))] = *application.ID
+			}
+		}
+		if !result.NotDone() {
+			break
+		}
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("getManagedApplicationsByResourceGroupUncached", "paging_error", err)
+			return nil, err
+		}
+	}
+
+	return applications, nil
+}
+
+//// UTILITY FUNCTIONS
+
+func denyAssignmentInfo(denyAssignment *armauthorization.DenyAssignment) DenyAssignmentInfo {
+	item := DenyAssignmentInfo{DenyAssignment: *denyAssignment}
+	if denyAssignment.Properties != nil {
+		item.Scope = denyAssignment.Properties.Scope
+	}
+	return item
+}
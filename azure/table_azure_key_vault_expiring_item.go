@@ -0,0 +1,641 @@
+package azure
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/keyvault/mgmt/keyvault"
+	secret "github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// KeyVaultExpiringItem is a secret, key or certificate of a vault with its expiry and rotation status
+type KeyVaultExpiringItem struct {
+	Name            *string
+	ID              *string
+	ItemType        string
+	VaultName       *string
+	Enabled         *bool
+	ContentType     *string
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+	NotBefore       *time.Time
+	ExpiresAt       *time.Time
+	DaysToExpiry    *int64
+	RotationEnabled *bool
+	RotationPolicy  interface{}
+	Tags            map[string]*string
+	Vault           keyvault.Resource
+}
+
+//// TABLE DEFINITION
+
+func tableAzureKeyVaultExpiringItem(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_key_vault_expiring_item",
+		Description: "Azure Key Vault Expiring Item",
+		List: &plugin.ListConfig{
+			Hydrate: listKeyVaultExpiringItems,
+			// The action is taken from the matrix item, so that secrets and certificates are listed under the
+			// azure_key_vault_secret rate limiter and keys under the azure_key_vault_key rate limiter
+			Tags: map[string]string{
+				"service": "Microsoft.KeyVault",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "vault_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "item_type",
+					Require: plugin.Optional,
+				},
+				{
+					Name:      "days_to_expiry",
+					Require:   plugin.Optional,
+					Operators: []string{"<", "<=", "="},
+				},
+			},
+		},
+		GetMatrixItemFunc: buildKeyVaultExpiringItemMatrix,
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The friendly name that identifies the item.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The identifier of the item in the vault.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "item_type",
+				Description: "The type of the item. Possible values are secret, key and certificate.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vault_name",
+				Description: "The friendly name that identifies the vault.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "enabled",
+				Description: "Indicates whether the item is enabled, or not.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "expires_at",
+				Description: "Specifies the time when the item will expire.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "days_to_expiry",
+				Description: "The number of whole days until the item expires. Negative if the item has already expired, and null if it has no expiry.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "rotation_enabled",
+				Description: "Indicates whether the item is rotated automatically, i.e. the key rotation policy has a rotate action, or the certificate policy has an auto-renew action. Null for secrets, which have no rotation policy.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "rotation_policy",
+				Description: "The rotation policy of a key, or the lifetime actions of the policy of a certificate.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "content_type",
+				Description: "Specifies the type of the secret value such as a password.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "created_at",
+				Description: "Specifies the time when the item is created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "updated_at",
+				Description: "Specifies the time when the item was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "not_before",
+				Description: "Specifies the time before which the item is not usable.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: ColumnDescriptionTags,
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(keyVaultExpiringItemAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Vault.Location").Transform(toLower),
+			},
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Vault.ID").Transform(extractResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// MATRIX FUNCTION
+
+// buildKeyVaultExpiringItemMatrix returns an item per vault and item type, so that the vaults are listed concurrently.
+// The subscription, vault and action of each item are the scope values of the Key Vault rate limiters.
+func buildKeyVaultExpiringItemMatrix(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	vaults, err := getKeyVaultExpiringItemVaults(ctx, d, nil)
+	if err != nil {
+		// The error is returned by the list function, as the matrix function cannot return it
+		plugin.Logger(ctx).Error("azure_key_vault_expiring_item.buildKeyVaultExpiringItemMatrix", "api_error", err)
+		return nil
+	}
+
+	vaultName := d.EqualsQualString("vault_name")
+	matrix := []map[string]interface{}{}
+	for _, vault := range vaults.([]keyvault.Resource) {
+		if vault.Name == nil || vault.ID == nil {
+			continue
+		}
+		if vaultName != "" && vaultName != *vault.Name {
+			continue
+		}
+
+		subscriptionID := strings.Split(*vault.ID, "/")[2]
+		for itemType, action := range map[string]string{
+			"secret":      "vaults/secrets/read",
+			"key":         "vaults/keys/read",
+			"certificate": "vaults/secrets/read",
+		} {
+			matrix = append(matrix, map[string]interface{}{
+				"subscription": subscriptionID,
+				"vault":        *vault.Name,
+				"item_type":    itemType,
+				"action":       action,
+			})
+		}
+	}
+
+	return matrix
+}
+
+//// LIST FUNCTION
+
+func listKeyVaultExpiringItems(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	matrixItem := plugin.GetMatrixItem(ctx)
+
+	// The matrix is empty if there are no vaults, or if listing them failed
+	if matrixItem == nil {
+		_, err := getKeyVaultExpiringItemVaults(ctx, d, h)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringItems", "api_error", err)
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	vaults, err := getKeyVaultExpiringItemVaults(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringItems", "api_error", err)
+		return nil, err
+	}
+
+	var vault *keyvault.Resource
+	for _, v := range vaults.([]keyvault.Resource) {
+		if v.Name != nil && *v.Name == matrixItem["vault"].(string) {
+			vault = &v
+			break
+		}
+	}
+	if vault == nil {
+		return nil, nil
+	}
+
+	switch matrixItem["item_type"].(string) {
+	case "secret":
+		return nil, listKeyVaultExpiringSecrets(ctx, d, *vault)
+	case "key":
+		return nil, listKeyVaultExpiringKeys(ctx, d, *vault)
+	case "certificate":
+		return nil, listKeyVaultExpiringCertificates(ctx, d, *vault)
+	}
+
+	return nil, nil
+}
+
+func listKeyVaultExpiringSecrets(ctx context.Context, d *plugin.QueryData, vault keyvault.Resource) error {
+	session, err := GetNewSession(ctx, d, "VAULT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringSecrets", "session_error", err)
+		return err
+	}
+
+	client := secret.New()
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	vaultURI := "https://" + *vault.Name + ".vault.azure.net/"
+	maxResults := int32(25)
+
+	result, err := client.GetSecrets(ctx, vaultURI, &maxResults)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringSecrets", "api_error", err)
+		return err
+	}
+
+	for {
+		for _, item := range result.Values() {
+			// The secrets backing certificates are reported as certificates
+			if item.ID == nil || (item.Managed != nil && *item.Managed) {
+				continue
+			}
+
+			row := KeyVaultExpiringItem{
+				ID:          item.ID,
+				ItemType:    "secret",
+				VaultName:   vault.Name,
+				ContentType: item.ContentType,
+				Tags:        item.Tags,
+				Vault:       vault,
+			}
+			row.Name = &strings.Split(*item.ID, "/")[4]
+			if item.Attributes != nil {
+				row.Enabled = item.Attributes.Enabled
+				row.CreatedAt = unixTimeToTime(item.Attributes.Created)
+				row.UpdatedAt = unixTimeToTime(item.Attributes.Updated)
+				row.NotBefore = unixTimeToTime(item.Attributes.NotBefore)
+				row.ExpiresAt = unixTimeToTime(item.Attributes.Expires)
+			}
+			row.DaysToExpiry = daysToExpiry(row.ExpiresAt)
+
+			if !keyVaultExpiringItemWithinThreshold(d, row.DaysToExpiry) {
+				continue
+			}
+
+			d.StreamListItem(ctx, row)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		if !result.NotDone() {
+			return nil
+		}
+
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringSecrets", "api_error", err)
+			return err
+		}
+	}
+}
+
+func listKeyVaultExpiringKeys(ctx context.Context, d *plugin.QueryData, vault keyvault.Resource) error {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringKeys", "session_error", err)
+		return err
+	}
+	resourceGroup := strings.Split(*vault.ID, "/")[4]
+
+	client := keyvault.NewKeysClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	result, err := client.List(ctx, resourceGroup, *vault.Name)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringKeys", "api_error", err)
+		return err
+	}
+
+	for {
+		for _, item := range result.Values() {
+			if item.Name == nil {
+				continue
+			}
+
+			row := KeyVaultExpiringItem{
+				Name:      item.Name,
+				ID:        item.ID,
+				ItemType:  "key",
+				VaultName: vault.Name,
+				Tags:      item.Tags,
+				Vault:     vault,
+			}
+			if item.KeyProperties != nil {
+				// Report the key identifier of the vault, as for secrets and certificates
+				if item.KeyProperties.KeyURI != nil {
+					row.ID = item.KeyProperties.KeyURI
+				}
+				if attributes := item.KeyProperties.Attributes; attributes != nil {
+					row.Enabled = attributes.Enabled
+					row.CreatedAt = epochToTime(attributes.Created)
+					row.UpdatedAt = epochToTime(attributes.Updated)
+					row.NotBefore = epochToTime(attributes.NotBefore)
+					row.ExpiresAt = epochToTime(attributes.Expires)
+				}
+			}
+			row.DaysToExpiry = daysToExpiry(row.ExpiresAt)
+
+			if !keyVaultExpiringItemWithinThreshold(d, row.DaysToExpiry) {
+				continue
+			}
+
+			// The rotation policy is only returned when getting the key
+			if keyVaultExpiringItemRotationRequested(d) {
+				// Wait for rate limiting
+				d.WaitForListRateLimit(ctx)
+
+				key, err := client.Get(ctx, resourceGroup, *vault.Name, *item.Name)
+				if err != nil {
+					plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringKeys", "api_error", err)
+					return err
+				}
+
+				rotationEnabled := false
+				if key.KeyProperties != nil && key.KeyProperties.RotationPolicy != nil {
+					row.RotationPolicy = key.KeyProperties.RotationPolicy
+					if actions := key.KeyProperties.RotationPolicy.LifetimeActions; actions != nil {
+						for _, action := range *actions {
+							if action.Action != nil && strings.EqualFold(string(action.Action.Type), string(keyvault.Rotate)) {
+								rotationEnabled = true
+							}
+						}
+					}
+				}
+				row.RotationEnabled = &rotationEnabled
+			}
+
+			d.StreamListItem(ctx, row)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		if !result.NotDone() {
+			return nil
+		}
+
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringKeys", "api_error", err)
+			return err
+		}
+	}
+}
+
+func listKeyVaultExpiringCertificates(ctx context.Context, d *plugin.QueryData, vault keyvault.Resource) error {
+	session, err := GetNewSession(ctx, d, "VAULT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringCertificates", "session_error", err)
+		return err
+	}
+
+	client := secret.New()
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	vaultURI := "https://" + *vault.Name + ".vault.azure.net/"
+	maxResults := int32(25)
+
+	result, err := client.GetCertificates(ctx, vaultURI, &maxResults, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringCertificates", "api_error", err)
+		return err
+	}
+
+	for {
+		for _, item := range result.Values() {
+			if item.ID == nil {
+				continue
+			}
+
+			row := KeyVaultExpiringItem{
+				ID:        item.ID,
+				ItemType:  "certificate",
+				VaultName: vault.Name,
+				Tags:      item.Tags,
+				Vault:     vault,
+			}
+			row.Name = &strings.Split(*item.ID, "/")[4]
+			if item.Attributes != nil {
+				row.Enabled = item.Attributes.Enabled
+				row.CreatedAt = unixTimeToTime(item.Attributes.Created)
+				row.UpdatedAt = unixTimeToTime(item.Attributes.Updated)
+				row.NotBefore = unixTimeToTime(item.Attributes.NotBefore)
+				row.ExpiresAt = unixTimeToTime(item.Attributes.Expires)
+			}
+			row.DaysToExpiry = daysToExpiry(row.ExpiresAt)
+
+			if !keyVaultExpiringItemWithinThreshold(d, row.DaysToExpiry) {
+				continue
+			}
+
+			// The lifetime actions are only returned with the certificate policy
+			if keyVaultExpiringItemRotationRequested(d) {
+				// Wait for rate limiting
+				d.WaitForListRateLimit(ctx)
+
+				policy, err := client.GetCertificatePolicy(ctx, vaultURI, *row.Name)
+				if err != nil {
+					plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringCertificates", "api_error", err)
+					return err
+				}
+
+				rotationEnabled := false
+				if policy.LifetimeActions != nil {
+					row.RotationPolicy = policy.LifetimeActions
+					for _, action := range *policy.LifetimeActions {
+						if action.Action != nil && action.Action.ActionType == secret.AutoRenew {
+							rotationEnabled = true
+						}
+					}
+				}
+				row.RotationEnabled = &rotationEnabled
+			}
+
+			d.StreamListItem(ctx, row)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		if !result.NotDone() {
+			return nil
+		}
+
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_key_vault_expiring_item.listKeyVaultExpiringCertificates", "api_error", err)
+			return err
+		}
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+// if the caching is required other than per connection, build a cache key for the call and use it in Memoize.
+var getKeyVaultExpiringItemVaultsMemoized = plugin.HydrateFunc(getKeyVaultExpiringItemVaultsUncached).Memoize(memoize.WithCacheKeyFunction(getKeyVaultExpiringItemVaultsCacheKey))
+
+// declare a wrapper hydrate function to call the memoized function
+// - this is required when a memoized function is used for a column definition
+func getKeyVaultExpiringItemVaults(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getKeyVaultExpiringItemVaultsMemoized(ctx, d, h)
+}
+
+// Build a cache key for the call to getKeyVaultExpiringItemVaults.
+func getKeyVaultExpiringItemVaultsCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := "getKeyVaultExpiringItemVaults"
+	return key, nil
+}
+
+func getKeyVaultExpiringItemVaultsUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		return nil, err
+	}
+
+	client := keyvault.NewVaultsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+	maxResults := int32(100)
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	result, err := client.List(ctx, &maxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	vaults := []keyvault.Resource{}
+	vaults = append(vaults, result.Values()...)
+	for result.NotDone() {
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		vaults = append(vaults, result.Values()...)
+	}
+
+	return vaults, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func keyVaultExpiringItemAkas(ctx context.Context, d *transform.TransformData) (interface{}, error) {
+	item := d.HydrateItem.(KeyVaultExpiringItem)
+	if item.Vault.ID == nil || item.Name == nil {
+		return nil, nil
+	}
+
+	id := *item.Vault.ID + "/" + item.ItemType + "s/" + *item.Name
+	return []string{"azure://" + id, "azure://" + strings.ToLower(id)}, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// keyVaultExpiringItemWithinThreshold reports whether an item expires within the days_to_expiry given in the quals.
+// Items that do not expire never satisfy a threshold.
+func keyVaultExpiringItemWithinThreshold(d *plugin.QueryData, days *int64) bool {
+	if d.Quals["days_to_expiry"] == nil {
+		return true
+	}
+	if days == nil {
+		return false
+	}
+
+	for _, q := range d.Quals["days_to_expiry"].Quals {
+		threshold := q.Value.GetInt64Value()
+		switch q.Operator {
+		case "<":
+			if *days >= threshold {
+				return false
+			}
+		case "<=", "=":
+			if *days > threshold {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// keyVaultExpiringItemRotationRequested reports whether the query needs the rotation policies, which take a call per item
+func keyVaultExpiringItemRotationRequested(d *plugin.QueryData) bool {
+	return slices.Contains(d.QueryContext.Columns, "rotation_enabled") || slices.Contains(d.QueryContext.Columns, "rotation_policy")
+}
+
+func daysToExpiry(expiresAt *time.Time) *int64 {
+	if expiresAt == nil {
+		return nil
+	}
+	days := int64(math.Floor(time.Until(*expiresAt).Hours() / 24))
+	return &days
+}
+
+func unixTimeToTime(t *date.UnixTime) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := time.Time(*t)
+	return &value
+}
+
+func epochToTime(seconds *int64) *time.Time {
+	if seconds == nil || *seconds == 0 {
+		return nil
+	}
+	value := time.Unix(*seconds, 0)
+	return &value
+}
@@ -0,0 +1,184 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/operationalinsights/v1/operationalinsights"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type logAnalyticsQueryRow struct {
+	WorkspaceID   string
+	Query         string
+	Timespan      *string
+	TableName     *string
+	TimeGenerated *time.Time
+	Row           map[string]interface{}
+}
+
+//// TABLE DEFINITION
+
+func tableAzureLogAnalyticsQuery(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_log_analytics_query",
+		Description: "Azure Log Analytics Query",
+		List: &plugin.ListConfig{
+			Hydrate: listLogAnalyticsQueryResults,
+			Tags: map[string]string{
+				"service": "Microsoft.OperationalInsights",
+				"action":  "workspaces/query/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "workspace_id",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "query",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "timespan",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "workspace_id",
+				Description: "The workspace (customer) ID of the Log Analytics workspace the query is run against.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("WorkspaceID"),
+			},
+			{
+				Name:        "query",
+				Description: "The Kusto Query Language (KQL) query to run.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "timespan",
+				Description: "The ISO 8601 time period the query is limited to, e.g. P1D or 2024-01-01/2024-01-02. It is applied in addition to any time filter in the query.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "table_name",
+				Description: "The name of the result table the row belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "time_generated",
+				Description: "The value of the TimeGenerated column of the row, if returned by the query.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "row",
+				Description: "The row returned by the query as a JSON object keyed by column name.",
+				Type:        proto.ColumnType_JSON,
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listLogAnalyticsQueryResults(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	workspaceID := d.EqualsQualString("workspace_id")
+	query := d.EqualsQualString("query")
+	if workspaceID == "" || query == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "LOG_ANALYTICS")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_log_analytics_query.listLogAnalyticsQueryResults", "session_error", err)
+		return nil, err
+	}
+
+	if session.OperationalInsightsEndpoint == "" || session.OperationalInsightsEndpoint == azure.NotAvailable {
+		return nil, fmt.Errorf("log analytics queries are not supported in the %s cloud environment", session.CloudEnvironment)
+	}
+
+	client := operationalinsights.NewQueryClientWithBaseURI(session.OperationalInsightsEndpoint + "/v1")
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	body := operationalinsights.QueryBody{
+		Query: &query,
+	}
+	timespan := d.EqualsQualString("timespan")
+	if timespan != "" {
+		body.Timespan = &timespan
+	}
+
+	result, err := client.Execute(ctx, workspaceID, body)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_log_analytics_query.listLogAnalyticsQueryResults", "api_error", err)
+		return nil, err
+	}
+
+	if result.Tables == nil {
+		return nil, nil
+	}
+
+	for _, table := range *result.Tables {
+		if table.Columns == nil || table.Rows == nil {
+			continue
+		}
+		for _, values := range *table.Rows {
+			row := logAnalyticsQueryRow{
+				WorkspaceID: workspaceID,
+				Query:       query,
+				TableName:   table.Name,
+				Row:         queryResultRowToMap(*table.Columns, values),
+			}
+			if timespan != "" {
+				row.Timespan = &timespan
+			}
+			row.TimeGenerated = parseQueryResultTime(row.Row["TimeGenerated"])
+
+			d.StreamListItem(ctx, row)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// queryResultRowToMap pairs the values of a query result row with the names of the result columns
+func queryResultRowToMap(columns []operationalinsights.Column, values []interface{}) map[string]interface{} {
+	row := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if column.Name == nil || i >= len(values) {
+			continue
+		}
+		row[*column.Name] = values[i]
+	}
+	return row
+}
+
+// parseQueryResultTime converts a datetime value returned by a KQL query into a time
+func parseQueryResultTime(value interface{}) *time.Time {
+	str, ok := value.(string)
+	if !ok || str == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
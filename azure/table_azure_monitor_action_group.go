@@ -0,0 +1,291 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureMonitorActionGroup(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_monitor_action_group",
+		Description: "Azure Monitor Action Group",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "resource_group"}),
+			Hydrate:    getMonitorActionGroup,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "actionGroups/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "ResourceGroupNotFound", "404"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMonitorActionGroups,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "actionGroups/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the action group.",
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the action group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "group_short_name",
+				Description: "The short name of the action group. This is used in SMS messages.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.GroupShortName"),
+			},
+			{
+				Name:        "enabled",
+				Description: "Indicates whether the action group is enabled. If an action group is not enabled, then none of its receivers will receive communications.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.Enabled"),
+			},
+			{
+				Name:        "receiver_count",
+				Description: "The total number of receivers of the action group.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.From(extractMonitorActionGroupReceiverCount),
+			},
+			{
+				Name:        "enabled_receiver_count",
+				Description: "The number of receivers of the action group that are not disabled. Email and SMS receivers can be disabled, e.g. when the recipient unsubscribes.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.From(extractMonitorActionGroupEnabledReceiverCount),
+			},
+			{
+				Name:        "email_receivers",
+				Description: "The list of email receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.EmailReceivers"),
+			},
+			{
+				Name:        "sms_receivers",
+				Description: "The list of SMS receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.SmsReceivers"),
+			},
+			{
+				Name:        "webhook_receivers",
+				Description: "The list of webhook receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.WebhookReceivers"),
+			},
+			{
+				Name:        "logic_app_receivers",
+				Description: "The list of logic app receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.LogicAppReceivers"),
+			},
+			{
+				Name:        "azure_function_receivers",
+				Description: "The list of azure function receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.AzureFunctionReceivers"),
+			},
+			{
+				Name:        "itsm_receivers",
+				Description: "The list of ITSM receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ItsmReceivers"),
+			},
+			{
+				Name:        "voice_receivers",
+				Description: "The list of voice receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.VoiceReceivers"),
+			},
+			{
+				Name:        "arm_role_receivers",
+				Description: "The list of ARM role receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ArmRoleReceivers"),
+			},
+			{
+				Name:        "automation_runbook_receivers",
+				Description: "The list of automation runbook receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.AutomationRunbookReceivers"),
+			},
+			{
+				Name:        "azure_app_push_receivers",
+				Description: "The list of Azure app push receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.AzureAppPushReceivers"),
+			},
+			{
+				Name:        "event_hub_receivers",
+				Description: "The list of event hub receivers that are part of this action group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.EventHubReceivers"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: ColumnDescriptionTags,
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Location").Transform(toLower),
+			},
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listMonitorActionGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_action_group.listMonitorActionGroups", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewActionGroupsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_action_group.listMonitorActionGroups", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListBySubscriptionIDPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_action_group.listMonitorActionGroups", "api_error", err)
+			return nil, err
+		}
+
+		for _, actionGroup := range page.Value {
+			d.StreamListItem(ctx, actionGroup)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getMonitorActionGroup(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	resourceGroup := d.EqualsQualString("resource_group")
+	if name == "" || resourceGroup == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_action_group.getMonitorActionGroup", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewActionGroupsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_action_group.getMonitorActionGroup", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, resourceGroup, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_action_group.getMonitorActionGroup", "api_error", err)
+		return nil, err
+	}
+
+	return &op.ActionGroupResource, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func extractMonitorActionGroupReceiverCount(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	total, _ := countMonitorActionGroupReceivers(d.HydrateItem.(*armmonitor.ActionGroupResource))
+	return total, nil
+}
+
+func extractMonitorActionGroupEnabledReceiverCount(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	_, enabled := countMonitorActionGroupReceivers(d.HydrateItem.(*armmonitor.ActionGroupResource))
+	return enabled, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// countMonitorActionGroupReceivers returns the total number of receivers of an action group and the number of receivers
+// that are not disabled. Only email and SMS receivers carry a status; all other receiver types are counted as enabled.
+func countMonitorActionGroupReceivers(actionGroup *armmonitor.ActionGroupResource) (int, int) {
+	if actionGroup == nil || actionGroup.Properties == nil {
+		return 0, 0
+	}
+	p := actionGroup.Properties
+
+	total, enabled := 0, 0
+	for _, receiver := range p.EmailReceivers {
+		total++
+		if receiver.Status == nil || *receiver.Status != armmonitor.ReceiverStatusDisabled {
+			enabled++
+		}
+	}
+	for _, receiver := range p.SmsReceivers {
+		total++
+		if receiver.Status == nil || *receiver.Status != armmonitor.ReceiverStatusDisabled {
+			enabled++
+		}
+	}
+
+	others := len(p.WebhookReceivers) + len(p.LogicAppReceivers) + len(p.AzureFunctionReceivers) + len(p.ItsmReceivers) +
+		len(p.VoiceReceivers) + len(p.ArmRoleReceivers) + len(p.AutomationRunbookReceivers) + len(p.AzureAppPushReceivers) +
+		len(p.EventHubReceivers)
+
+	return total + others, enabled + others
+}
@@ -0,0 +1,318 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// monitorAlertRoutingRow is an action group reference of an alert rule. Alert rules without any action group
+// reference are returned as a single row with an empty action group.
+type monitorAlertRoutingRow struct {
+	AlertRuleID          string
+	AlertRuleName        *string
+	AlertRuleType        string
+	AlertRuleEnabled     *bool
+	ActionGroupID        *string
+	ActionGroupName      *string
+	GroupShortName       *string
+	ActionGroupResolved  bool
+	ActionGroupEnabled   *bool
+	ReceiverCount        int
+	EnabledReceiverCount int
+	NotifiesNobody       *bool
+}
+
+const (
+	monitorAlertRuleTypeActivityLog    = "activity_log"
+	monitorAlertRuleTypeMetric         = "metric"
+	monitorAlertRuleTypeScheduledQuery = "scheduled_query"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureMonitorAlertRouting(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_monitor_alert_routing",
+		Description: "Azure Monitor Alert Routing",
+		List: &plugin.ListConfig{
+			Hydrate: listMonitorAlertRoutings,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "actionGroups/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "alert_rule_type",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "alert_rule_id",
+				Description: "The resource ID of the alert rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AlertRuleID"),
+			},
+			{
+				Name:        "alert_rule_name",
+				Description: "The name of the alert rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alert_rule_type",
+				Description: "The type of the alert rule. Possible values are: activity_log (azure_log_alert), metric (azure_monitor_metric_alert) and scheduled_query (azure_monitor_scheduled_query_rule).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alert_rule_enabled",
+				Description: "Indicates whether the alert rule is enabled.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "action_group_id",
+				Description: "The resource ID of the action group referenced by the alert rule. Null if the alert rule has no action group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ActionGroupID"),
+			},
+			{
+				Name:        "action_group_name",
+				Description: "The name of the action group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "group_short_name",
+				Description: "The short name of the action group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "action_group_resolved",
+				Description: "Indicates whether the referenced action group was found in the subscription. False if it has been deleted or belongs to another subscription.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "action_group_enabled",
+				Description: "Indicates whether the action group is enabled.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "receiver_count",
+				Description: "The total number of receivers of the action group.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "enabled_receiver_count",
+				Description: "The number of receivers of the action group that are not disabled.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "notifies_nobody",
+				Description: "True if this route reaches no one: the alert rule has no action group, or the action group is disabled or has no enabled receivers. Null if the action group could not be resolved, e.g. because it belongs to another subscription.",
+				Type:        proto.ColumnType_BOOL,
+			},
+
+			// Azure standard columns
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AlertRuleID").Transform(extractResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listMonitorAlertRoutings(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_alert_routing.listMonitorAlertRoutings", "session_error", err)
+		return nil, err
+	}
+
+	actionGroups, err := getMonitorActionGroupsByID(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_alert_routing.listMonitorAlertRoutings", "action_groups_error", err)
+		return nil, err
+	}
+	actionGroupMap := actionGroups.(map[string]*armmonitor.ActionGroupResource)
+
+	ruleType := d.EqualsQualString("alert_rule_type")
+
+	// streamRule emits one row per action group reference of the alert rule, or a single row if it has none.
+	// It returns false once no more rows are required.
+	streamRule := func(id *string, name *string, typ string, enabled *bool, actionGroupIDs []*string) bool {
+		if id == nil {
+			return true
+		}
+
+		var references []*string
+		for _, actionGroupID := range actionGroupIDs {
+			if actionGroupID != nil {
+				references = append(references, actionGroupID)
+			}
+		}
+		if len(references) == 0 {
+			references = []*string{nil}
+		}
+
+		for _, actionGroupID := range references {
+			d.StreamListItem(ctx, buildMonitorAlertRoutingRow(*id, name, typ, enabled, actionGroupID, actionGroupMap))
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	if ruleType == "" || ruleType == monitorAlertRuleTypeActivityLog {
+		client, err := armmonitor.NewActivityLogAlertsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_alert_routing.listMonitorAlertRoutings", "client_error", err)
+			return nil, err
+		}
+
+		pager := client.NewListBySubscriptionIDPager(nil)
+		for pager.More() {
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_monitor_alert_routing.listMonitorAlertRoutings", "activity_log_alert_api_error", err)
+				return nil, err
+			}
+
+			for _, alert := range page.Value {
+				var enabled *bool
+				var actionGroupIDs []*string
+				if alert.Properties != nil {
+					enabled = alert.Properties.Enabled
+					if alert.Properties.Actions != nil {
+						for _, actionGroup := range alert.Properties.Actions.ActionGroups {
+							if actionGroup != nil {
+								actionGroupIDs = append(actionGroupIDs, actionGroup.ActionGroupID)
+							}
+						}
+					}
+				}
+				if !streamRule(alert.ID, alert.Name, monitorAlertRuleTypeActivityLog, enabled, actionGroupIDs) {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	if ruleType == "" || ruleType == monitorAlertRuleTypeMetric {
+		client, err := armmonitor.NewMetricAlertsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_alert_routing.listMonitorAlertRoutings", "client_error", err)
+			return nil, err
+		}
+
+		pager := client.NewListBySubscriptionPager(nil)
+		for pager.More() {
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_monitor_alert_routing.listMonitorAlertRoutings", "metric_alert_api_error", err)
+				return nil, err
+			}
+
+			for _, alert := range page.Value {
+				var enabled *bool
+				var actionGroupIDs []*string
+				if alert.Properties != nil {
+					enabled = alert.Properties.Enabled
+					for _, action := range alert.Properties.Actions {
+						if action != nil {
+							actionGroupIDs = append(actionGroupIDs, action.ActionGroupID)
+						}
+					}
+				}
+				if !streamRule(alert.ID, alert.Name, monitorAlertRuleTypeMetric, enabled, actionGroupIDs) {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	if ruleType == "" || ruleType == monitorAlertRuleTypeScheduledQuery {
+		client, err := armmonitor.NewScheduledQueryRulesClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_alert_routing.listMonitorAlertRoutings", "client_error", err)
+			return nil, err
+		}
+
+		pager := client.NewListBySubscriptionPager(nil)
+		for pager.More() {
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_monitor_alert_routing.listMonitorAlertRoutings", "scheduled_query_rule_api_error", err)
+				return nil, err
+			}
+
+			for _, rule := range page.Value {
+				var enabled *bool
+				var actionGroupIDs []*string
+				if rule.Properties != nil {
+					enabled = rule.Properties.Enabled
+					if rule.Properties.Actions != nil {
+						actionGroupIDs = rule.Properties.Actions.ActionGroups
+					}
+				}
+				if !streamRule(rule.ID, rule.Name, monitorAlertRuleTypeScheduledQuery, enabled, actionGroupIDs) {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+func buildMonitorAlertRoutingRow(alertRuleID string, alertRuleName *string, alertRuleType string, alertRuleEnabled *bool, actionGroupID *string, actionGroups map[string]*armmonitor.ActionGroupResource) monitorAlertRoutingRow {
+	row := monitorAlertRoutingRow{
+		AlertRuleID:      alertRuleID,
+		AlertRuleName:    alertRuleName,
+		AlertRuleType:    alertRuleType,
+		AlertRuleEnabled: alertRuleEnabled,
+		ActionGroupID:    actionGroupID,
+	}
+	if actionGroupID == nil {
+		row.NotifiesNobody = to.Ptr(true)
+		return row
+	}
+
+	// An action group that cannot be resolved may live in another subscription, so whether it notifies anyone is unknown
+	actionGroup := resolveMonitorAlertActionGroup(*actionGroupID, actionGroups)
+	if !actionGroup.Resolved {
+		return row
+	}
+
+	row.ActionGroupResolved = true
+	row.ActionGroupName = actionGroup.Name
+	row.GroupShortName = actionGroup.GroupShortName
+	row.ActionGroupEnabled = actionGroup.Enabled
+	row.ReceiverCount, row.EnabledReceiverCount = countMonitorActionGroupReceivers(actionGroups[strings.ToLower(*actionGroupID)])
+	row.NotifiesNobody = to.Ptr(row.ActionGroupEnabled == nil || !*row.ActionGroupEnabled || row.EnabledReceiverCount == 0)
+
+	return row
+}
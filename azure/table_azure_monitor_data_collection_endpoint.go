@@ -0,0 +1,238 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureMonitorDataCollectionEndpoint(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_monitor_data_collection_endpoint",
+		Description: "Azure Monitor Data Collection Endpoint",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "resource_group"}),
+			Hydrate:    getMonitorDataCollectionEndpoint,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "dataCollectionEndpoints/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "ResourceGroupNotFound", "404"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMonitorDataCollectionEndpoints,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "dataCollectionEndpoints/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the data collection endpoint.",
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the data collection endpoint.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The kind of the resource, e.g. Linux or Windows.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "Description of the data collection endpoint.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "immutable_id",
+				Description: "The immutable ID of this data collection endpoint.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ImmutableID"),
+			},
+			{
+				Name:        "provisioning_state",
+				Description: "The resource provisioning state.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ProvisioningState"),
+			},
+			{
+				Name:        "configuration_access_endpoint",
+				Description: "The endpoint used by agents to access their configuration.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ConfigurationAccess.Endpoint"),
+			},
+			{
+				Name:        "logs_ingestion_endpoint",
+				Description: "The endpoint used by clients to ingest logs.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.LogsIngestion.Endpoint"),
+			},
+			{
+				Name:        "metrics_ingestion_endpoint",
+				Description: "The endpoint used by clients to ingest metrics.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.MetricsIngestion.Endpoint"),
+			},
+			{
+				Name:        "public_network_access",
+				Description: "The configuration to set whether network access from public internet to the endpoints are allowed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.NetworkACLs.PublicNetworkAccess"),
+			},
+			{
+				Name:        "etag",
+				Description: "Resource entity tag (ETag).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "failover_configuration",
+				Description: "Failover configuration on this endpoint.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.FailoverConfiguration"),
+			},
+			{
+				Name:        "private_link_scoped_resources",
+				Description: "List of Azure Monitor Private Link Scope resources to which this data collection endpoint resource is associated.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.PrivateLinkScopedResources"),
+			},
+			{
+				Name:        "metadata",
+				Description: "Metadata for the resource.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Metadata"),
+			},
+			{
+				Name:        "identity",
+				Description: "Managed service identity of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "system_data",
+				Description: "Metadata pertaining to creation and last modification of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: ColumnDescriptionTags,
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Location").Transform(toLower),
+			},
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listMonitorDataCollectionEndpoints(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_endpoint.listMonitorDataCollectionEndpoints", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewDataCollectionEndpointsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_endpoint.listMonitorDataCollectionEndpoints", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListBySubscriptionPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_data_collection_endpoint.listMonitorDataCollectionEndpoints", "api_error", err)
+			return nil, err
+		}
+
+		for _, endpoint := range page.Value {
+			d.StreamListItem(ctx, endpoint)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getMonitorDataCollectionEndpoint(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	resourceGroup := d.EqualsQualString("resource_group")
+	if name == "" || resourceGroup == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_endpoint.getMonitorDataCollectionEndpoint", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewDataCollectionEndpointsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_endpoint.getMonitorDataCollectionEndpoint", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, resourceGroup, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_endpoint.getMonitorDataCollectionEndpoint", "api_error", err)
+		return nil, err
+	}
+
+	return &op.DataCollectionEndpointResource, nil
+}
@@ -0,0 +1,353 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// DataCollectionStreamDestination is a stream of a data collection rule data flow, paired with one of the destinations it is sent to
+type DataCollectionStreamDestination struct {
+	Stream          string
+	OutputStream    *string
+	DestinationName string
+	DestinationType string
+	ResourceID      *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureMonitorDataCollectionRule(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_monitor_data_collection_rule",
+		Description: "Azure Monitor Data Collection Rule",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "resource_group"}),
+			Hydrate:    getMonitorDataCollectionRule,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "dataCollectionRules/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "ResourceGroupNotFound", "404"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMonitorDataCollectionRules,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "dataCollectionRules/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the data collection rule.",
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the data collection rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The kind of the resource, e.g. Linux or Windows.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "Description of the data collection rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "immutable_id",
+				Description: "The immutable ID of this data collection rule. This property is READ-ONLY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ImmutableID"),
+			},
+			{
+				Name:        "provisioning_state",
+				Description: "The resource provisioning state.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ProvisioningState"),
+			},
+			{
+				Name:        "data_collection_endpoint_id",
+				Description: "The resource ID of the data collection endpoint that this rule can be used with.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DataCollectionEndpointID"),
+			},
+			{
+				Name:        "etag",
+				Description: "Resource entity tag (ETag).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "data_sources",
+				Description: "The specification of data sources, e.g. performance counters, Windows event logs and syslog.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.DataSources"),
+			},
+			{
+				Name:        "destinations",
+				Description: "The specification of destinations, e.g. Log Analytics workspaces and Azure Monitor Metrics.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Destinations"),
+			},
+			{
+				Name:        "data_flows",
+				Description: "The specification of data flows, i.e. which streams are sent to which destinations.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.DataFlows"),
+			},
+			{
+				Name:        "stream_destinations",
+				Description: "The data flows of the rule flattened to one entry per stream and destination, with the resource ID of the destination.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(extractDataCollectionRuleStreamDestinations),
+			},
+			{
+				Name:        "log_analytics_workspace_ids",
+				Description: "The resource IDs of the Log Analytics workspaces the rule sends data to.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Destinations.LogAnalytics").Transform(extractDataCollectionRuleWorkspaceIDs),
+			},
+			{
+				Name:        "stream_declarations",
+				Description: "Declaration of custom streams used in this rule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.StreamDeclarations"),
+			},
+			{
+				Name:        "metadata",
+				Description: "Metadata about the resource.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Metadata"),
+			},
+			{
+				Name:        "identity",
+				Description: "Managed service identity of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "system_data",
+				Description: "Metadata pertaining to creation and last modification of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: ColumnDescriptionTags,
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Location").Transform(toLower),
+			},
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listMonitorDataCollectionRules(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_rule.listMonitorDataCollectionRules", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewDataCollectionRulesClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_rule.listMonitorDataCollectionRules", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListBySubscriptionPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_data_collection_rule.listMonitorDataCollectionRules", "api_error", err)
+			return nil, err
+		}
+
+		for _, rule := range page.Value {
+			d.StreamListItem(ctx, rule)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getMonitorDataCollectionRule(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	resourceGroup := d.EqualsQualString("resource_group")
+	if name == "" || resourceGroup == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_rule.getMonitorDataCollectionRule", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewDataCollectionRulesClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_rule.getMonitorDataCollectionRule", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, resourceGroup, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_rule.getMonitorDataCollectionRule", "api_error", err)
+		return nil, err
+	}
+
+	return &op.DataCollectionRuleResource, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func extractDataCollectionRuleWorkspaceIDs(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	destinations, ok := d.Value.([]*armmonitor.LogAnalyticsDestination)
+	if !ok {
+		return nil, nil
+	}
+
+	var workspaceIDs []string
+	for _, destination := range destinations {
+		if destination != nil && destination.WorkspaceResourceID != nil {
+			workspaceIDs = append(workspaceIDs, *destination.WorkspaceResourceID)
+		}
+	}
+	return workspaceIDs, nil
+}
+
+func extractDataCollectionRuleStreamDestinations(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	rule := d.HydrateItem.(*armmonitor.DataCollectionRuleResource)
+	if rule.Properties == nil {
+		return nil, nil
+	}
+
+	// Map destination names to their type and resource ID
+	type destination struct {
+		destinationType string
+		resourceID      *string
+	}
+	destinations := map[string]destination{}
+	if p := rule.Properties.Destinations; p != nil {
+		for _, dest := range p.LogAnalytics {
+			if dest != nil && dest.Name != nil {
+				destinations[*dest.Name] = destination{"LogAnalytics", dest.WorkspaceResourceID}
+			}
+		}
+		if p.AzureMonitorMetrics != nil && p.AzureMonitorMetrics.Name != nil {
+			destinations[*p.AzureMonitorMetrics.Name] = destination{"AzureMonitorMetrics", nil}
+		}
+		for _, dest := range p.EventHubs {
+			if dest != nil && dest.Name != nil {
+				destinations[*dest.Name] = destination{"EventHubs", dest.EventHubResourceID}
+			}
+		}
+		for _, dest := range p.EventHubsDirect {
+			if dest != nil && dest.Name != nil {
+				destinations[*dest.Name] = destination{"EventHubsDirect", dest.EventHubResourceID}
+			}
+		}
+		for _, dest := range p.MonitoringAccounts {
+			if dest != nil && dest.Name != nil {
+				destinations[*dest.Name] = destination{"MonitoringAccounts", dest.AccountResourceID}
+			}
+		}
+		for _, dest := range p.StorageAccounts {
+			if dest != nil && dest.Name != nil {
+				destinations[*dest.Name] = destination{"StorageAccounts", dest.StorageAccountResourceID}
+			}
+		}
+		for _, dest := range p.StorageBlobsDirect {
+			if dest != nil && dest.Name != nil {
+				destinations[*dest.Name] = destination{"StorageBlobsDirect", dest.StorageAccountResourceID}
+			}
+		}
+		for _, dest := range p.StorageTablesDirect {
+			if dest != nil && dest.Name != nil {
+				destinations[*dest.Name] = destination{"StorageTablesDirect", dest.StorageAccountResourceID}
+			}
+		}
+	}
+
+	var result []DataCollectionStreamDestination
+	for _, flow := range rule.Properties.DataFlows {
+		if flow == nil {
+			continue
+		}
+		for _, stream := range flow.Streams {
+			if stream == nil {
+				continue
+			}
+			for _, destinationName := range flow.Destinations {
+				if destinationName == nil {
+					continue
+				}
+				item := DataCollectionStreamDestination{
+					Stream:          string(*stream),
+					OutputStream:    flow.OutputStream,
+					DestinationName: *destinationName,
+				}
+				if dest, ok := destinations[*destinationName]; ok {
+					item.DestinationType = dest.destinationType
+					item.ResourceID = dest.resourceID
+				}
+				result = append(result, item)
+			}
+		}
+	}
+
+	return result, nil
+}
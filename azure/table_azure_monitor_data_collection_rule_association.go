@@ -0,0 +1,241 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// MonitorDataCollectionRuleAssociationInfo is a data collection rule association along with the resource it is associated with
+type MonitorDataCollectionRuleAssociationInfo struct {
+	ResourceID string
+	armmonitor.DataCollectionRuleAssociationProxyOnlyResource
+}
+
+//// TABLE DEFINITION
+
+func tableAzureMonitorDataCollectionRuleAssociation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_monitor_data_collection_rule_association",
+		Description: "Azure Monitor Data Collection Rule Association",
+		List: &plugin.ListConfig{
+			Hydrate: listMonitorDataCollectionRuleAssociations,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "dataCollectionRuleAssociations/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "resource_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the association.",
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the association.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource the data collection rule or endpoint is associated with, e.g. a virtual machine or an Arc-enabled server.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceID"),
+			},
+			{
+				Name:        "data_collection_rule_id",
+				Description: "The resource ID of the data collection rule that is to be associated.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DataCollectionRuleID"),
+			},
+			{
+				Name:        "data_collection_endpoint_id",
+				Description: "The resource ID of the data collection endpoint that is to be associated.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DataCollectionEndpointID"),
+			},
+			{
+				Name:        "description",
+				Description: "Description of the association.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "provisioning_state",
+				Description: "The resource provisioning state.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ProvisioningState"),
+			},
+			{
+				Name:        "etag",
+				Description: "Resource entity tag (ETag).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "metadata",
+				Description: "Metadata about the resource.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Metadata"),
+			},
+			{
+				Name:        "system_data",
+				Description: "Metadata pertaining to creation and last modification of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractOptionalResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listMonitorDataCollectionRuleAssociations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_rule_association.listMonitorDataCollectionRuleAssociations", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewDataCollectionRuleAssociationsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_rule_association.listMonitorDataCollectionRuleAssociations", "client_error", err)
+		return nil, err
+	}
+
+	// If a resource ID is specified, list the associations of that resource only. This also returns
+	// associations with data collection endpoints, which are not listed per data collection rule.
+	resourceID := d.EqualsQualString("resource_id")
+	if resourceID != "" {
+		pager := client.NewListByResourcePager(strings.TrimPrefix(resourceID, "/"), nil)
+		for pager.More() {
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_monitor_data_collection_rule_association.listMonitorDataCollectionRuleAssociations", "api_error", err)
+				return nil, err
+			}
+
+			for _, association := range page.Value {
+				d.StreamListItem(ctx, buildMonitorDataCollectionRuleAssociationInfo(d, association))
+
+				// Check if context has been cancelled or if the limit has been hit (if specified)
+				// if there is a limit, it will return the number of rows required to reach this limit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	rulesClient, err := armmonitor.NewDataCollectionRulesClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_data_collection_rule_association.listMonitorDataCollectionRuleAssociations", "client_error", err)
+		return nil, err
+	}
+
+	rulesPager := rulesClient.NewListBySubscriptionPager(nil)
+	for rulesPager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		rulesPage, err := rulesPager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_data_collection_rule_association.listMonitorDataCollectionRuleAssociations", "rules_api_error", err)
+			return nil, err
+		}
+
+		for _, rule := range rulesPage.Value {
+			if rule.ID == nil || rule.Name == nil {
+				continue
+			}
+			ruleID, err := arm.ParseResourceID(*rule.ID)
+			if err != nil {
+				plugin.Logger(ctx).Warn("azure_monitor_data_collection_rule_association.listMonitorDataCollectionRuleAssociations", "invalid_rule_id", *rule.ID, "error", err)
+				continue
+			}
+
+			pager := client.NewListByRulePager(ruleID.ResourceGroupName, *rule.Name, nil)
+			for pager.More() {
+				// Wait for rate limiting
+				d.WaitForListRateLimit(ctx)
+
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					plugin.Logger(ctx).Error("azure_monitor_data_collection_rule_association.listMonitorDataCollectionRuleAssociations", "api_error", err)
+					return nil, err
+				}
+
+				for _, association := range page.Value {
+					d.StreamListItem(ctx, buildMonitorDataCollectionRuleAssociationInfo(d, association))
+
+					// Check if context has been cancelled or if the limit has been hit (if specified)
+					// if there is a limit, it will return the number of rows required to reach this limit
+					if d.RowsRemaining(ctx) == 0 {
+						return nil, nil
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// buildMonitorDataCollectionRuleAssociationInfo extracts the associated resource from the ID of an association, which has the form
+// {resourceId}/providers/Microsoft.Insights/dataCollectionRuleAssociations/{associationName}.
+// The resource ID is reported with the casing used in the quals, since it is returned in the casing of the association.
+func buildMonitorDataCollectionRuleAssociationInfo(d *plugin.QueryData, association *armmonitor.DataCollectionRuleAssociationProxyOnlyResource) MonitorDataCollectionRuleAssociationInfo {
+	info := MonitorDataCollectionRuleAssociationInfo{
+		DataCollectionRuleAssociationProxyOnlyResource: *association,
+	}
+	if association.ID != nil {
+		index := strings.Index(strings.ToLower(*association.ID), "/providers/microsoft.insights/datacollectionruleassociations/")
+		if index > 0 {
+			info.ResourceID = getQualValueCasing(d, "resource_id", (*association.ID)[:index])
+		}
+	}
+	return info
+}
@@ -0,0 +1,370 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// MonitorAlertActionGroup is an action group referenced by an alert rule, resolved against the action groups of the subscription.
+// Resolved is false if the action group could not be found, e.g. because it has been deleted or belongs to another subscription.
+type MonitorAlertActionGroup struct {
+	ActionGroupID     string
+	Name              *string
+	ResourceGroup     *string
+	GroupShortName    *string
+	Enabled           *bool
+	Resolved          bool
+	WebHookProperties map[string]*string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureMonitorMetricAlert(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_monitor_metric_alert",
+		Description: "Azure Monitor Metric Alert",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "resource_group"}),
+			Hydrate:    getMonitorMetricAlert,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "metricAlerts/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "ResourceGroupNotFound", "404"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMonitorMetricAlerts,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "metricAlerts/read",
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getMonitorMetricAlertActionGroups,
+				Tags: map[string]string{
+					"service": "Microsoft.Insights",
+					"action":  "actionGroups/read",
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the metric alert rule.",
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the metric alert rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the metric alert rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "enabled",
+				Description: "Indicates whether the metric alert rule is enabled.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.Enabled"),
+			},
+			{
+				Name:        "severity",
+				Description: "The severity of the alert, from 0 (critical) to 4 (verbose).",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.Severity"),
+			},
+			{
+				Name:        "evaluation_frequency",
+				Description: "How often the metric alert is evaluated, represented in ISO 8601 duration format.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.EvaluationFrequency"),
+			},
+			{
+				Name:        "window_size",
+				Description: "The period of time used to monitor alert activity based on the threshold, represented in ISO 8601 duration format.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.WindowSize"),
+			},
+			{
+				Name:        "auto_mitigate",
+				Description: "Indicates whether the alert should be auto resolved.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.AutoMitigate"),
+			},
+			{
+				Name:        "target_resource_type",
+				Description: "The resource type of the target resource(s) on which the alert is created/updated. Mandatory if the scope contains a subscription, resource group, or more than one resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.TargetResourceType"),
+			},
+			{
+				Name:        "target_resource_region",
+				Description: "The region of the target resource(s) on which the alert is created/updated. Mandatory if the scope contains a subscription, resource group, or more than one resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.TargetResourceRegion"),
+			},
+			{
+				Name:        "is_migrated",
+				Description: "Indicates whether the alert resource was migrated from a classic alert rule.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.IsMigrated"),
+			},
+			{
+				Name:        "last_updated_time",
+				Description: "The time the metric alert rule was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.LastUpdatedTime"),
+			},
+			{
+				Name:        "scopes",
+				Description: "The list of resource IDs that this metric alert is scoped to.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Scopes"),
+			},
+			{
+				Name:        "criteria",
+				Description: "The criteria that define when the alert fires.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Criteria"),
+			},
+			{
+				Name:        "actions",
+				Description: "The array of actions that are performed when the alert rule becomes active, and when an alert condition is resolved.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Actions"),
+			},
+			{
+				Name:        "action_groups",
+				Description: "The action groups referenced by the alert rule, resolved to their name, short name and enabled state.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getMonitorMetricAlertActionGroups,
+				Transform:   transform.FromValue(),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: ColumnDescriptionTags,
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Location").Transform(toLower),
+			},
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listMonitorMetricAlerts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_metric_alert.listMonitorMetricAlerts", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewMetricAlertsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_metric_alert.listMonitorMetricAlerts", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListBySubscriptionPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_metric_alert.listMonitorMetricAlerts", "api_error", err)
+			return nil, err
+		}
+
+		for _, alert := range page.Value {
+			d.StreamListItem(ctx, alert)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getMonitorMetricAlert(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	resourceGroup := d.EqualsQualString("resource_group")
+	if name == "" || resourceGroup == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_metric_alert.getMonitorMetricAlert", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewMetricAlertsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_metric_alert.getMonitorMetricAlert", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, resourceGroup, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_metric_alert.getMonitorMetricAlert", "api_error", err)
+		return nil, err
+	}
+
+	return &op.MetricAlertResource, nil
+}
+
+func getMonitorMetricAlertActionGroups(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	alert := h.Item.(*armmonitor.MetricAlertResource)
+	if alert.Properties == nil || len(alert.Properties.Actions) == 0 {
+		return nil, nil
+	}
+
+	actionGroups, err := getMonitorActionGroupsByID(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []MonitorAlertActionGroup
+	for _, action := range alert.Properties.Actions {
+		if action == nil || action.ActionGroupID == nil {
+			continue
+		}
+		actionGroup := resolveMonitorAlertActionGroup(*action.ActionGroupID, actionGroups.(map[string]*armmonitor.ActionGroupResource))
+		actionGroup.WebHookProperties = action.WebHookProperties
+		result = append(result, actionGroup)
+	}
+
+	return result, nil
+}
+
+// if the caching is required other than per connection, build a cache key for the call and use it in Memoize.
+var getMonitorActionGroupsByIDMemoized = plugin.HydrateFunc(getMonitorActionGroupsByIDUncached).Memoize(memoize.WithCacheKeyFunction(getMonitorActionGroupsByIDCacheKey))
+
+// declare a wrapper hydrate function to call the memoized function
+// - this is required when a memoized function is used for a column definition
+func getMonitorActionGroupsByID(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getMonitorActionGroupsByIDMemoized(ctx, d, h)
+}
+
+// Build a cache key for the call to getMonitorActionGroupsByID.
+func getMonitorActionGroupsByIDCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := "getMonitorActionGroupsByID"
+	return key, nil
+}
+
+// getMonitorActionGroupsByIDUncached lists the action groups of the subscription, keyed by lower case resource ID,
+// so that alert rules can resolve their action group references without a get call per reference.
+func getMonitorActionGroupsByIDUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getMonitorActionGroupsByIDUncached", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewActionGroupsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("getMonitorActionGroupsByIDUncached", "client_error", err)
+		return nil, err
+	}
+
+	actionGroups := make(map[string]*armmonitor.ActionGroupResource)
+	pager := client.NewListBySubscriptionIDPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("getMonitorActionGroupsByIDUncached", "api_error", err)
+			return nil, err
+		}
+		for _, actionGroup := range page.Value {
+			if actionGroup.ID != nil {
+				actionGroups[strings.ToLower(*actionGroup.ID)] = actionGroup
+			}
+		}
+	}
+
+	return actionGroups, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// resolveMonitorAlertActionGroup looks up an action group reference of an alert rule
+func resolveMonitorAlertActionGroup(actionGroupID string, actionGroups map[string]*armmonitor.ActionGroupResource) MonitorAlertActionGroup {
+	result := MonitorAlertActionGroup{
+		ActionGroupID: actionGroupID,
+	}
+
+	actionGroup, ok := actionGroups[strings.ToLower(actionGroupID)]
+	if !ok || actionGroup.ID == nil {
+		return result
+	}
+
+	// Action groups with a malformed ID are reported as unresolved
+	parsedID, err := arm.ParseResourceID(*actionGroup.ID)
+	if err != nil {
+		return result
+	}
+
+	result.Resolved = true
+	result.Name = actionGroup.Name
+	resourceGroup := strings.ToLower(parsedID.ResourceGroupName)
+	result.ResourceGroup = &resourceGroup
+	if actionGroup.Properties != nil {
+		result.GroupShortName = actionGroup.Properties.GroupShortName
+		result.Enabled = actionGroup.Properties.Enabled
+	}
+
+	return result
+}
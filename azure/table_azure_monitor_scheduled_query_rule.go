@@ -0,0 +1,324 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureMonitorScheduledQueryRule(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_monitor_scheduled_query_rule",
+		Description: "Azure Monitor Scheduled Query Rule",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"name", "resource_group"}),
+			Hydrate:    getMonitorScheduledQueryRule,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "scheduledQueryRules/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "ResourceGroupNotFound", "404"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMonitorScheduledQueryRules,
+			Tags: map[string]string{
+				"service": "Microsoft.Insights",
+				"action":  "scheduledQueryRules/read",
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getMonitorScheduledQueryRuleActionGroups,
+				Tags: map[string]string{
+					"service": "Microsoft.Insights",
+					"action":  "actionGroups/read",
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the scheduled query rule.",
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the scheduled query rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "Indicates the type of scheduled query rule. The default is LogAlert.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the alert rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the scheduled query rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "enabled",
+				Description: "Indicates whether the scheduled query rule is enabled.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.Enabled"),
+			},
+			{
+				Name:        "severity",
+				Description: "The severity of the alert, from 0 (critical) to 4 (verbose). Relevant and required only for rules of the kind LogAlert.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.Severity"),
+			},
+			{
+				Name:        "evaluation_frequency",
+				Description: "How often the scheduled query rule is evaluated, represented in ISO 8601 duration format.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.EvaluationFrequency"),
+			},
+			{
+				Name:        "window_size",
+				Description: "The period of time (in ISO 8601 duration format) on which the alert query will be executed (bin size).",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.WindowSize"),
+			},
+			{
+				Name:        "override_query_time_range",
+				Description: "If specified, overrides the query time range (default is window_size), represented in ISO 8601 duration format.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.OverrideQueryTimeRange"),
+			},
+			{
+				Name:        "mute_actions_duration",
+				Description: "Mute actions for the chosen period of time (in ISO 8601 duration format) after the alert is fired.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.MuteActionsDuration"),
+			},
+			{
+				Name:        "auto_mitigate",
+				Description: "Indicates whether the alert should be automatically resolved.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.AutoMitigate"),
+			},
+			{
+				Name:        "skip_query_validation",
+				Description: "Indicates whether the provided query should be validated or not.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.SkipQueryValidation"),
+			},
+			{
+				Name:        "check_workspace_alerts_storage_configured",
+				Description: "Indicates whether this scheduled query rule should be stored in the customer's storage.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.CheckWorkspaceAlertsStorageConfigured"),
+			},
+			{
+				Name:        "is_workspace_alerts_storage_configured",
+				Description: "Indicates whether this scheduled query rule has been configured to be stored in the customer's storage.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.IsWorkspaceAlertsStorageConfigured"),
+			},
+			{
+				Name:        "is_legacy_log_analytics_rule",
+				Description: "Indicates whether this rule is a legacy Log Analytics rule.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.IsLegacyLogAnalyticsRule"),
+			},
+			{
+				Name:        "created_with_api_version",
+				Description: "The API version used when creating this alert rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.CreatedWithAPIVersion"),
+			},
+			{
+				Name:        "etag",
+				Description: "The etag field is not required. If it is provided in the response body, it must also be provided as a header per the normal etag convention.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scopes",
+				Description: "The list of resource IDs that this scheduled query rule is scoped to.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Scopes"),
+			},
+			{
+				Name:        "target_resource_types",
+				Description: "List of resource types of the target resource(s) on which the alert is created/updated.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.TargetResourceTypes"),
+			},
+			{
+				Name:        "criteria",
+				Description: "The rule criteria that defines the conditions of the scheduled query rule, including the query.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Criteria"),
+			},
+			{
+				Name:        "actions",
+				Description: "Actions to invoke when the alert fires, including the action group IDs and custom properties.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Actions"),
+			},
+			{
+				Name:        "action_groups",
+				Description: "The action groups referenced by the rule, resolved to their name, short name and enabled state.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getMonitorScheduledQueryRuleActionGroups,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "identity",
+				Description: "The identity of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "system_data",
+				Description: "Metadata pertaining to creation and last modification of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: ColumnDescriptionTags,
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Location").Transform(toLower),
+			},
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listMonitorScheduledQueryRules(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_scheduled_query_rule.listMonitorScheduledQueryRules", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewScheduledQueryRulesClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_scheduled_query_rule.listMonitorScheduledQueryRules", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListBySubscriptionPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_monitor_scheduled_query_rule.listMonitorScheduledQueryRules", "api_error", err)
+			return nil, err
+		}
+
+		for _, rule := range page.Value {
+			d.StreamListItem(ctx, rule)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getMonitorScheduledQueryRule(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	resourceGroup := d.EqualsQualString("resource_group")
+	if name == "" || resourceGroup == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_scheduled_query_rule.getMonitorScheduledQueryRule", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armmonitor.NewScheduledQueryRulesClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_scheduled_query_rule.getMonitorScheduledQueryRule", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, resourceGroup, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_monitor_scheduled_query_rule.getMonitorScheduledQueryRule", "api_error", err)
+		return nil, err
+	}
+
+	return &op.ScheduledQueryRuleResource, nil
+}
+
+func getMonitorScheduledQueryRuleActionGroups(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	rule := h.Item.(*armmonitor.ScheduledQueryRuleResource)
+	if rule.Properties == nil || rule.Properties.Actions == nil || len(rule.Properties.Actions.ActionGroups) == 0 {
+		return nil, nil
+	}
+
+	actionGroups, err := getMonitorActionGroupsByID(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []MonitorAlertActionGroup
+	for _, actionGroupID := range rule.Properties.Actions.ActionGroups {
+		if actionGroupID == nil {
+			continue
+		}
+		result = append(result, resolveMonitorAlertActionGroup(*actionGroupID, actionGroups.(map[string]*armmonitor.ActionGroupResource)))
+	}
+
+	return result, nil
+}
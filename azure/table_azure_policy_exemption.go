@@ -0,0 +1,273 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/resources/mgmt/2021-06-01-preview/policy"
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// PolicyExemptionInfo is a policy exemption, with the management group it was listed at
+type PolicyExemptionInfo struct {
+	policy.Exemption
+	ManagementGroupID *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzurePolicyExemption(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_policy_exemption",
+		Description: "Azure Policy Exemption",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getPolicyExemption,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "policyExemptions/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"PolicyExemptionNotFound", "ResourceNotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listPolicyExemptions,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "policyExemptions/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "management_group_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "policy_assignment_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the policy exemption.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Description: "The name of the policy exemption.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the policy exemption.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ExemptionProperties.DisplayName"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the policy exemption.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ExemptionProperties.Description"),
+			},
+			{
+				Name:        "exemption_category",
+				Description: "The policy exemption category. Possible values are Waiver and Mitigated.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ExemptionProperties.ExemptionCategory"),
+			},
+			{
+				Name:        "expires_on",
+				Description: "The expiration date and time of the policy exemption.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("ExemptionProperties.ExpiresOn").Transform(convertDateToTime),
+			},
+			{
+				Name:        "scope",
+				Description: "The scope the policy exemption is defined at.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractPolicyExemptionScope),
+			},
+			{
+				Name:        "policy_assignment_id",
+				Description: "The ID of the policy assignment that is being exempted.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ExemptionProperties.PolicyAssignmentID"),
+			},
+			{
+				Name:        "policy_definition_reference_ids",
+				Description: "The policy definition reference IDs of the exempted policy definitions, when the associated policy assignment is an assignment of a policy set definition. Empty if the whole policy assignment is exempted.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ExemptionProperties.PolicyDefinitionReferenceIds"),
+			},
+			{
+				Name:        "management_group_id",
+				Description: "The management group the policy exemptions were listed at, or the management group the policy exemption is defined at for get calls.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ManagementGroupID"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Authorization/policyExemptions).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "metadata",
+				Description: "The policy exemption metadata. Metadata is an open ended object and is typically a collection of key value pairs.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ExemptionProperties.Metadata"),
+			},
+			{
+				Name:        "system_data",
+				Description: "The system metadata relating to the policy exemption.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ExemptionProperties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPolicyExemptions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_exemption.listPolicyExemptions", "session_error", err)
+		return nil, err
+	}
+
+	client := policy.NewExemptionsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	filter := ""
+	if policyAssignmentID := d.EqualsQualString("policy_assignment_id"); policyAssignmentID != "" {
+		filter = fmt.Sprintf("policyAssignmentId eq '%s'", strings.ReplaceAll(policyAssignmentID, "'", "''"))
+	}
+
+	var managementGroupID *string
+	var result policy.ExemptionListResultPage
+	if mgID := d.EqualsQualString("management_group_id"); mgID != "" {
+		managementGroupID = &mgID
+		result, err = client.ListForManagementGroup(ctx, mgID, filter)
+	} else {
+		result, err = client.List(ctx, filter)
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_exemption.listPolicyExemptions", "api_error", err)
+		return nil, err
+	}
+
+	for _, exemption := range result.Values() {
+		d.StreamListItem(ctx, policyExemptionInfo(d, exemption, managementGroupID))
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	for result.NotDone() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_policy_exemption.listPolicyExemptions", "paging_error", err)
+			return nil, err
+		}
+
+		for _, exemption := range result.Values() {
+			d.StreamListItem(ctx, policyExemptionInfo(d, exemption, managementGroupID))
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getPolicyExemption(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+	scope := getPolicyExemptionScope(id)
+	if scope == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_exemption.getPolicyExemption", "session_error", err)
+		return nil, err
+	}
+
+	client := policy.NewExemptionsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	// The scope is inserted in the path as is, so it must not start with a "/" (forward slash)
+	op, err := client.Get(ctx, strings.TrimPrefix(scope, "/"), getLastPathElement(id))
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_exemption.getPolicyExemption", "api_error", err)
+		return nil, err
+	}
+
+	return PolicyExemptionInfo{op, getPolicyManagementGroupID(id)}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func extractPolicyExemptionScope(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	scope := getPolicyExemptionScope(types.SafeString(d.Value))
+	if scope == "" {
+		return nil, nil
+	}
+	return scope, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// policyExemptionInfo wraps a policy exemption, reporting the filtered policy assignment ID with the casing used in the quals
+func policyExemptionInfo(d *plugin.QueryData, exemption policy.Exemption, managementGroupID *string) PolicyExemptionInfo {
+	if exemption.ExemptionProperties != nil && exemption.PolicyAssignmentID != nil {
+		policyAssignmentID := getQualValueCasing(d, "policy_assignment_id", *exemption.PolicyAssignmentID)
+		exemption.PolicyAssignmentID = &policyAssignmentID
+	}
+	return PolicyExemptionInfo{exemption, managementGroupID}
+}
+
+// getPolicyExemptionScope returns the scope part of a policy exemption ID
+func getPolicyExemptionScope(id string) string {
+	index := strings.Index(strings.ToLower(id), "/providers/microsoft.authorization/policyexemptions/")
+	if index < 0 {
+		return ""
+	}
+	return id[:index]
+}
@@ -0,0 +1,286 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// PolicyRemediationInfo is a policy remediation, with the management group it was listed at
+type PolicyRemediationInfo struct {
+	policyinsights.Remediation
+	ManagementGroupID *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzurePolicyRemediation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_policy_remediation",
+		Description: "Azure Policy Remediation",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getPolicyRemediation,
+			Tags: map[string]string{
+				"service": "Microsoft.PolicyInsights",
+				"action":  "remediations/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"RemediationNotFound", "ResourceNotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listPolicyRemediations,
+			Tags: map[string]string{
+				"service": "Microsoft.PolicyInsights",
+				"action":  "remediations/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "management_group_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "policy_assignment_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the remediation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Description: "The name of the remediation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "provisioning_state",
+				Description: "The status of the remediation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RemediationProperties.ProvisioningState"),
+			},
+			{
+				Name:        "policy_assignment_id",
+				Description: "The ID of the policy assignment that is remediated.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RemediationProperties.PolicyAssignmentID"),
+			},
+			{
+				Name:        "policy_definition_reference_id",
+				Description: "The policy definition reference ID of the policy definition that is remediated, when the policy assignment assigns a policy set definition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RemediationProperties.PolicyDefinitionReferenceID"),
+			},
+			{
+				Name:        "resource_discovery_mode",
+				Description: "The way resources to remediate are discovered. Possible values are ExistingNonCompliant and ReEvaluateCompliance.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RemediationProperties.ResourceDiscoveryMode"),
+			},
+			{
+				Name:        "created_on",
+				Description: "The time at which the remediation was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("RemediationProperties.CreatedOn").Transform(convertDateToTime),
+			},
+			{
+				Name:        "last_updated_on",
+				Description: "The time at which the remediation was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("RemediationProperties.LastUpdatedOn").Transform(convertDateToTime),
+			},
+			{
+				Name:        "total_deployments",
+				Description: "The number of deployments required by the remediation.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("RemediationProperties.DeploymentStatus.TotalDeployments"),
+			},
+			{
+				Name:        "successful_deployments",
+				Description: "The number of deployments required by the remediation that have succeeded.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("RemediationProperties.DeploymentStatus.SuccessfulDeployments"),
+			},
+			{
+				Name:        "failed_deployments",
+				Description: "The number of deployments required by the remediation that have failed.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("RemediationProperties.DeploymentStatus.FailedDeployments"),
+			},
+			{
+				Name:        "scope",
+				Description: "The scope the remediation is defined at.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractPolicyRemediationScope),
+			},
+			{
+				Name:        "management_group_id",
+				Description: "The management group the remediations were listed at, or the management group the remediation is defined at for get calls.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ManagementGroupID"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.PolicyInsights/remediations).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "filters",
+				Description: "The filters applied to determine which resources to remediate.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("RemediationProperties.Filters"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPolicyRemediations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_remediation.listPolicyRemediations", "session_error", err)
+		return nil, err
+	}
+
+	client := policyinsights.NewRemediationsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	filter := ""
+	if policyAssignmentID := d.EqualsQualString("policy_assignment_id"); policyAssignmentID != "" {
+		filter = fmt.Sprintf("PolicyAssignmentId eq '%s'", strings.ReplaceAll(policyAssignmentID, "'", "''"))
+	}
+
+	var managementGroupID *string
+	var result policyinsights.RemediationListResultPage
+	if mgID := d.EqualsQualString("management_group_id"); mgID != "" {
+		managementGroupID = &mgID
+		result, err = client.ListForManagementGroup(ctx, mgID, nil, filter)
+	} else {
+		result, err = client.ListForSubscription(ctx, session.SubscriptionID, nil, filter)
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_remediation.listPolicyRemediations", "api_error", err)
+		return nil, err
+	}
+
+	for _, remediation := range result.Values() {
+		d.StreamListItem(ctx, policyRemediationInfo(d, remediation, managementGroupID))
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	for result.NotDone() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_policy_remediation.listPolicyRemediations", "paging_error", err)
+			return nil, err
+		}
+
+		for _, remediation := range result.Values() {
+			d.StreamListItem(ctx, policyRemediationInfo(d, remediation, managementGroupID))
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getPolicyRemediation(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+	scope := getPolicyRemediationScope(id)
+	if scope == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_remediation.getPolicyRemediation", "session_error", err)
+		return nil, err
+	}
+
+	client := policyinsights.NewRemediationsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	// The resource scope variant of the API accepts any scope, i.e. management group, subscription, resource group or resource
+	op, err := client.GetAtResource(ctx, strings.TrimPrefix(scope, "/"), getLastPathElement(id))
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_remediation.getPolicyRemediation", "api_error", err)
+		return nil, err
+	}
+
+	return PolicyRemediationInfo{op, getPolicyManagementGroupID(id)}, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func extractPolicyRemediationScope(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	scope := getPolicyRemediationScope(types.SafeString(d.Value))
+	if scope == "" {
+		return nil, nil
+	}
+	return scope, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// policyRemediationInfo wraps a remediation, reporting the filtered policy assignment ID with the casing used in the quals
+func policyRemediationInfo(d *plugin.QueryData, remediation policyinsights.Remediation, managementGroupID *string) PolicyRemediationInfo {
+	if remediation.RemediationProperties != nil && remediation.PolicyAssignmentID != nil {
+		policyAssignmentID := getQualValueCasing(d, "policy_assignment_id", *remediation.PolicyAssignmentID)
+		remediation.PolicyAssignmentID = &policyAssignmentID
+	}
+	return PolicyRemediationInfo{remediation, managementGroupID}
+}
+
+// getPolicyRemediationScope returns the scope part of a remediation ID
+func getPolicyRemediationScope(id string) string {
+	index := strings.Index(strings.ToLower(id), "/providers/microsoft.policyinsights/remediations/")
+	if index < 0 {
+		return ""
+	}
+	return id[:index]
+}
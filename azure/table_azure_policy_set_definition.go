@@ -0,0 +1,445 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/resources/mgmt/2021-06-01-preview/policy"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// PolicySetDefinitionInfo is a policy set definition, with the management group it was listed at
+type PolicySetDefinitionInfo struct {
+	policy.SetDefinition
+	ManagementGroupID *string
+}
+
+// PolicySetDefinitionMember is a policy definition of a policy set, resolved to the policy definition details
+type PolicySetDefinitionMember struct {
+	PolicyDefinitionID          *string
+	PolicyDefinitionReferenceID *string
+	GroupNames                  *[]string
+	Parameters                  map[string]*policy.ParameterValuesValue
+	DisplayName                 *string
+	Description                 *string
+	PolicyType                  policy.Type
+	Mode                        *string
+	Effect                      interface{}
+	Resolved                    bool
+}
+
+var policyParameterExpression = regexp.MustCompile(`^\[parameters\('([^']+)'\)\]$`)
+
+//// TABLE DEFINITION
+
+func tableAzurePolicySetDefinition(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_policy_set_definition",
+		Description: "Azure Policy Set Definition",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getPolicySetDefinition,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "policySetDefinitions/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"PolicySetDefinitionNotFound", "ResourceNotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listPolicySetDefinitions,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "policySetDefinitions/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "management_group_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "policy_type",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "category",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the policy set definition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Description: "The name of the policy set definition.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the policy set definition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SetDefinitionProperties.DisplayName"),
+			},
+			{
+				Name:        "description",
+				Description: "The policy set definition description.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SetDefinitionProperties.Description"),
+			},
+			{
+				Name:        "policy_type",
+				Description: "The type of policy set definition. Possible values are NotSpecified, BuiltIn, Custom, and Static.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SetDefinitionProperties.PolicyType"),
+			},
+			{
+				Name:        "category",
+				Description: "The category of the policy set definition, from its metadata.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SetDefinitionProperties.Metadata").Transform(extractPolicyMetadataCategory),
+			},
+			{
+				Name:        "management_group_id",
+				Description: "The management group the policy set definitions were listed at, or the management group the policy set definition is defined at for get calls.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ManagementGroupID"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Authorization/policySetDefinitions).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "metadata",
+				Description: "The policy set definition metadata. Metadata is an open ended object and is typically a collection of key value pairs.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("SetDefinitionProperties.Metadata"),
+			},
+			{
+				Name:        "parameters",
+				Description: "The policy set definition parameters that can be used in policy definition references.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("SetDefinitionProperties.Parameters"),
+			},
+			{
+				Name:        "policy_definition_references",
+				Description: "The policy definition references of the policy set, as defined in the policy set definition.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("SetDefinitionProperties.PolicyDefinitions"),
+			},
+			{
+				Name:        "policy_definitions",
+				Description: "The policy definitions of the policy set, resolved to their display name, policy type, mode and effect.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getPolicySetDefinitionMembers,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "policy_definition_groups",
+				Description: "The metadata describing groups of policy definition references within the policy set definition.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("SetDefinitionProperties.PolicyDefinitionGroups"),
+			},
+			{
+				Name:        "system_data",
+				Description: "The system metadata relating to the policy set definition.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SetDefinitionProperties.DisplayName"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPolicySetDefinitions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_set_definition.listPolicySetDefinitions", "session_error", err)
+		return nil, err
+	}
+
+	client := policy.NewSetDefinitionsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	// The API supports a single filter condition
+	filter := ""
+	if policyType := d.EqualsQualString("policy_type"); policyType != "" {
+		filter = fmt.Sprintf("policyType eq '%s'", strings.ReplaceAll(policyType, "'", "''"))
+	} else if category := d.EqualsQualString("category"); category != "" {
+		filter = fmt.Sprintf("category eq '%s'", strings.ReplaceAll(category, "'", "''"))
+	}
+
+	var managementGroupID *string
+	var result policy.SetDefinitionListResultPage
+	if mgID := d.EqualsQualString("management_group_id"); mgID != "" {
+		managementGroupID = &mgID
+		result, err = client.ListByManagementGroup(ctx, mgID, filter, nil)
+	} else {
+		result, err = client.List(ctx, filter, nil)
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_set_definition.listPolicySetDefinitions", "api_error", err)
+		return nil, err
+	}
+
+	for _, setDefinition := range result.Values() {
+		d.StreamListItem(ctx, PolicySetDefinitionInfo{setDefinition, managementGroupID})
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	for result.NotDone() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_policy_set_definition.listPolicySetDefinitions", "paging_error", err)
+			return nil, err
+		}
+
+		for _, setDefinition := range result.Values() {
+			d.StreamListItem(ctx, PolicySetDefinitionInfo{setDefinition, managementGroupID})
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getPolicySetDefinition(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+	if id == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_set_definition.getPolicySetDefinition", "session_error", err)
+		return nil, err
+	}
+
+	client := policy.NewSetDefinitionsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	name := getLastPathElement(id)
+	managementGroupID := getPolicyManagementGroupID(id)
+
+	var op policy.SetDefinition
+	switch {
+	case managementGroupID != nil:
+		op, err = client.GetAtManagementGroup(ctx, name, *managementGroupID)
+	case strings.HasPrefix(strings.ToLower(id), "/providers/"):
+		op, err = client.GetBuiltIn(ctx, name)
+	default:
+		op, err = client.Get(ctx, name)
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_set_definition.getPolicySetDefinition", "api_error", err)
+		return nil, err
+	}
+
+	return PolicySetDefinitionInfo{op, managementGroupID}, nil
+}
+
+func getPolicySetDefinitionMembers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	setDefinition := h.Item.(PolicySetDefinitionInfo)
+	if setDefinition.SetDefinitionProperties == nil || setDefinition.PolicyDefinitions == nil {
+		return nil, nil
+	}
+
+	definitions, err := getPolicyDefinitionsByID(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []PolicySetDefinitionMember
+	for _, reference := range *setDefinition.PolicyDefinitions {
+		members = append(members, resolvePolicySetDefinitionMember(reference, definitions.(map[string]policy.Definition)))
+	}
+
+	return members, nil
+}
+
+// if the caching is required other than per connection, build a cache key for the call and use it in Memoize.
+var getPolicyDefinitionsByIDMemoized = plugin.HydrateFunc(getPolicyDefinitionsByIDUncached).Memoize(memoize.WithCacheKeyFunction(getPolicyDefinitionsByIDCacheKey))
+
+// declare a wrapper hydrate function to call the memoized function
+// - this is required when a memoized function is used for a column definition
+func getPolicyDefinitionsByID(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getPolicyDefinitionsByIDMemoized(ctx, d, h)
+}
+
+// Build a cache key for the call to getPolicyDefinitionsByID.
+// The definitions visible at a management group differ from those visible at the subscription.
+func getPolicyDefinitionsByIDCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := "getPolicyDefinitionsByID"
+	if item, ok := h.Item.(PolicySetDefinitionInfo); ok && item.ManagementGroupID != nil {
+		key = key + "/" + *item.ManagementGroupID
+	}
+	return key, nil
+}
+
+// getPolicyDefinitionsByIDUncached lists the policy definitions visible at the scope of the policy set, keyed by lower case
+// resource ID, so that policy sets can resolve their members without a get call per policy definition reference.
+func getPolicyDefinitionsByIDUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("getPolicyDefinitionsByIDUncached", "session_error", err)
+		return nil, err
+	}
+
+	client := policy.NewDefinitionsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	var result policy.DefinitionListResultPage
+	if item, ok := h.Item.(PolicySetDefinitionInfo); ok && item.ManagementGroupID != nil {
+		result, err = client.ListByManagementGroup(ctx, *item.ManagementGroupID, "", nil)
+	} else {
+		result, err = client.List(ctx, "", nil)
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error("getPolicyDefinitionsByIDUncached", "api_error", err)
+		return nil, err
+	}
+
+	definitions := make(map[string]policy.Definition)
+	for {
+		for _, definition := range result.Values() {
+			if definition.ID != nil {
+				definitions[strings.ToLower(*definition.ID)] = definition
+			}
+		}
+		if !result.NotDone() {
+			break
+		}
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("getPolicyDefinitionsByIDUncached", "paging_error", err)
+			return nil, err
+		}
+	}
+
+	return definitions, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func extractPolicyMetadataCategory(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	metadata, ok := d.Value.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	return metadata["category"], nil
+}
+
+//// UTILITY FUNCTIONS
+
+// resolvePolicySetDefinitionMember looks up a policy definition reference of a policy set
+func resolvePolicySetDefinitionMember(reference policy.DefinitionReference, definitions map[string]policy.Definition) PolicySetDefinitionMember {
+	member := PolicySetDefinitionMember{
+		PolicyDefinitionID:          reference.PolicyDefinitionID,
+		PolicyDefinitionReferenceID: reference.PolicyDefinitionReferenceID,
+		GroupNames:                  reference.GroupNames,
+		Parameters:                  reference.Parameters,
+	}
+	if reference.PolicyDefinitionID == nil {
+		return member
+	}
+
+	definition, ok := definitions[strings.ToLower(*reference.PolicyDefinitionID)]
+	if !ok || definition.DefinitionProperties == nil {
+		return member
+	}
+
+	member.Resolved = true
+	member.DisplayName = definition.DisplayName
+	member.Description = definition.Description
+	member.PolicyType = definition.PolicyType
+	member.Mode = definition.Mode
+	member.Effect = resolvePolicyEffect(definition, reference.Parameters)
+
+	return member
+}
+
+// resolvePolicyEffect returns the effect of the policy rule of a policy definition. Effects are usually parameterized,
+// in which case the effect is the parameter value given by the policy set, or the default value of the parameter.
+// The value passed by the policy set may itself be an expression over the policy set parameters, and is returned as is.
+func resolvePolicyEffect(definition policy.Definition, parameters map[string]*policy.ParameterValuesValue) interface{} {
+	rule, ok := definition.PolicyRule.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	then, ok := rule["then"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	effect, ok := then["effect"].(string)
+	if !ok {
+		return then["effect"]
+	}
+
+	match := policyParameterExpression.FindStringSubmatch(effect)
+	if match == nil {
+		return effect
+	}
+	if value, ok := parameters[match[1]]; ok && value != nil {
+		return value.Value
+	}
+	if parameter, ok := definition.Parameters[match[1]]; ok && parameter != nil {
+		return parameter.DefaultValue
+	}
+	return effect
+}
+
+// getPolicyManagementGroupID returns the management group of a resource ID defined at management group scope
+func getPolicyManagementGroupID(id string) *string {
+	segments := strings.Split(id, "/")
+	if len(segments) > 4 && strings.EqualFold(segments[1], "providers") && strings.EqualFold(segments[2], "Microsoft.Management") && strings.EqualFold(segments[3], "managementGroups") {
+		return &segments[4]
+	}
+	return nil
+}
@@ -0,0 +1,445 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/policyinsights/mgmt/2020-07-01-preview/policyinsights"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// policyStateFilterFields maps the columns that are pushed down into the OData filter to the policy state fields
+var policyStateFilterFields = map[string]string{
+	"policy_assignment_id": "policyAssignmentId",
+	"resource_type":        "resourceType",
+	"compliance_state":     "complianceState",
+	"resource_group":       "resourceGroup",
+}
+
+// PolicyStateInfo is the latest policy state of a resource, or the compliance summary of a policy assignment in summarize mode
+type PolicyStateInfo struct {
+	policyinsights.PolicyState
+	Summarize             bool
+	NonCompliantResources *int32
+	NonCompliantPolicies  *int32
+	ResourceDetails       *[]policyinsights.ComplianceDetail
+	PolicyDetails         *[]policyinsights.ComplianceDetail
+	PolicyGroupDetails    *[]policyinsights.ComplianceDetail
+}
+
+//// TABLE DEFINITION
+
+func tableAzurePolicyState(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_policy_state",
+		Description: "Azure Policy State",
+		List: &plugin.ListConfig{
+			Hydrate: listPolicyStates,
+			Tags: map[string]string{
+				"service": "Microsoft.PolicyInsights",
+				"action":  "policyStates/queryResults/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "policy_assignment_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "resource_type",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "compliance_state",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "resource_group",
+					Require: plugin.Optional,
+				},
+				{
+					Name:      "timestamp",
+					Require:   plugin.Optional,
+					Operators: []string{">", ">=", "<", "<="},
+				},
+				{
+					Name:    "summarize",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "resource_id",
+				Description: "The ID of the evaluated resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceID"),
+			},
+			{
+				Name:        "policy_assignment_id",
+				Description: "The ID of the policy assignment the resource was evaluated against.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PolicyAssignmentID"),
+			},
+			{
+				Name:        "policy_definition_id",
+				Description: "The ID of the evaluated policy definition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PolicyDefinitionID"),
+			},
+			{
+				Name:        "policy_definition_reference_id",
+				Description: "The reference ID of the policy definition inside the policy set, if the policy assignment is for a policy set.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PolicyDefinitionReferenceID"),
+			},
+			{
+				Name:        "policy_set_definition_id",
+				Description: "The ID of the policy set definition, if the policy assignment is for a policy set.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PolicySetDefinitionID"),
+			},
+			{
+				Name:        "compliance_state",
+				Description: "The compliance state of the resource, e.g. Compliant, NonCompliant, Exempt or Unknown.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "timestamp",
+				Description: "The time of the policy state record.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Timestamp").Transform(convertDateToTime),
+			},
+			{
+				Name:        "summarize",
+				Description: "If true, the table returns one row per policy assignment with the compliance counts instead of the policy states of the resources.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the evaluated resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_group",
+				Description: "The resource group of the evaluated resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_location",
+				Description: "The location of the evaluated resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_assignment_name",
+				Description: "The name of the policy assignment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_assignment_scope",
+				Description: "The scope of the policy assignment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_definition_name",
+				Description: "The name of the policy definition.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_definition_action",
+				Description: "The effect of the policy definition, e.g. audit or deny.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_definition_category",
+				Description: "The category of the policy definition.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_definition_group_names",
+				Description: "The policy definition group names of the policy definition inside the policy set.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "policy_set_definition_name",
+				Description: "The name of the policy set definition, if the policy assignment is for a policy set.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_set_definition_category",
+				Description: "The category of the policy set definition, if the policy assignment is for a policy set.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "management_group_ids",
+				Description: "A comma separated list of the management groups the resource is under.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_evaluation_details",
+				Description: "The details of the policy evaluation, such as the expressions that caused the resource to be non-compliant.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "non_compliant_resources",
+				Description: "The number of non-compliant resources of the policy assignment, in summarize mode.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "non_compliant_policies",
+				Description: "The number of non-compliant policy definitions of the policy assignment, in summarize mode.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "resource_details",
+				Description: "The number of resources per compliance state of the policy assignment, in summarize mode.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "policy_details",
+				Description: "The number of policy definitions per compliance state of the policy assignment, in summarize mode.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "policy_group_details",
+				Description: "The number of policy groups per compliance state of the policy assignment, in summarize mode.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(policyStateTitle),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPolicyStates(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_state.listPolicyStates", "session_error", err)
+		return nil, err
+	}
+
+	subscriptionID := session.SubscriptionID
+	client := policyinsights.NewPolicyStatesClientWithBaseURI(session.ResourceManagerEndpoint, subscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	filter := buildPolicyStateFilter(d)
+	from, to := getPolicyStateTimeRange(d)
+
+	if d.EqualsQuals["summarize"] != nil && d.EqualsQuals["summarize"].GetBoolValue() {
+		if err := validatePolicyStateSummaryQuals(d); err != nil {
+			plugin.Logger(ctx).Error("azure_policy_state.listPolicyStates", "qual_error", err)
+			return nil, err
+		}
+		return nil, listPolicyStateSummaries(ctx, d, client, subscriptionID, from, to, filter)
+	}
+
+	result, err := client.ListQueryResultsForSubscription(ctx, policyinsights.Latest, subscriptionID, nil, "", "", from, to, filter, "", "")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_state.listPolicyStates", "api_error", err)
+		return nil, err
+	}
+
+	for _, state := range result.Values() {
+		d.StreamListItem(ctx, policyStateInfo(d, state))
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	for result.NotDone() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_policy_state.listPolicyStates", "paging_error", err)
+			return nil, err
+		}
+
+		for _, state := range result.Values() {
+			d.StreamListItem(ctx, policyStateInfo(d, state))
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// listPolicyStateSummaries streams one row per policy assignment with the compliance counts of the policy states matching the filter
+func listPolicyStateSummaries(ctx context.Context, d *plugin.QueryData, client policyinsights.PolicyStatesClient, subscriptionID string, from *date.Time, to *date.Time, filter string) error {
+	result, err := client.SummarizeForSubscription(ctx, subscriptionID, nil, from, to, filter)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_policy_state.listPolicyStateSummaries", "api_error", err)
+		return err
+	}
+	if result.Value == nil {
+		return nil
+	}
+
+	for _, summary := range *result.Value {
+		if summary.PolicyAssignments == nil {
+			continue
+		}
+		for _, assignment := range *summary.PolicyAssignments {
+			// The summary rows report the filtered values, so that they are not dropped when Postgres rechecks the quals
+			row := PolicyStateInfo{
+				PolicyState: policyinsights.PolicyState{
+					PolicyAssignmentID:    assignment.PolicyAssignmentID,
+					PolicySetDefinitionID: assignment.PolicySetDefinitionID,
+					ResourceType:          getPolicyStateSingleQualValue(d, "resource_type"),
+					ComplianceState:       getPolicyStateSingleQualValue(d, "compliance_state"),
+					ResourceGroup:         getPolicyStateSingleQualValue(d, "resource_group"),
+				},
+				Summarize: true,
+			}
+			if row.PolicyAssignmentID != nil {
+				row.PolicyAssignmentID = policyStateQualValueCasing(d, "policy_assignment_id", row.PolicyAssignmentID)
+			}
+			if assignment.Results != nil {
+				row.NonCompliantResources = assignment.Results.NonCompliantResources
+				row.NonCompliantPolicies = assignment.Results.NonCompliantPolicies
+				row.ResourceDetails = assignment.Results.ResourceDetails
+				row.PolicyDetails = assignment.Results.PolicyDetails
+				row.PolicyGroupDetails = assignment.Results.PolicyGroupDetails
+			}
+			d.StreamListItem(ctx, row)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func policyStateTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	state := d.HydrateItem.(PolicyStateInfo)
+	if state.Summarize && state.PolicyAssignmentID != nil {
+		return getLastPathElement(*state.PolicyAssignmentID), nil
+	}
+	if state.ResourceID != nil {
+		return getLastPathElement(*state.ResourceID), nil
+	}
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// policyStateInfo wraps a policy state, reporting the filtered values with the casing used in the quals
+func policyStateInfo(d *plugin.QueryData, state policyinsights.PolicyState) PolicyStateInfo {
+	state.PolicyAssignmentID = policyStateQualValueCasing(d, "policy_assignment_id", state.PolicyAssignmentID)
+	state.ResourceType = policyStateQualValueCasing(d, "resource_type", state.ResourceType)
+	state.ComplianceState = policyStateQualValueCasing(d, "compliance_state", state.ComplianceState)
+	state.ResourceGroup = policyStateQualValueCasing(d, "resource_group", state.ResourceGroup)
+	return PolicyStateInfo{PolicyState: state}
+}
+
+// policyStateQualValueCasing returns the qual value that matches the value case-insensitively, since Policy Insights
+// returns IDs and resource types in lower case while the filter is case insensitive
+func policyStateQualValueCasing(d *plugin.QueryData, column string, value *string) *string {
+	if value == nil {
+		return nil
+	}
+	v := getQualValueCasing(d, column, *value)
+	return &v
+}
+
+// validatePolicyStateSummaryQuals rejects the quals that would drop every summary row when Postgres rechecks them.
+// A summary row reports a single value per column, so it cannot match an IN list, and it has no timestamp.
+func validatePolicyStateSummaryQuals(d *plugin.QueryData) error {
+	if d.Quals["timestamp"] != nil {
+		return fmt.Errorf("timestamp quals are not supported with summarize = true, since the summaries have no timestamp")
+	}
+	for _, column := range []string{"resource_type", "compliance_state", "resource_group"} {
+		if d.EqualsQuals[column] != nil && d.EqualsQuals[column].GetListValue() != nil {
+			return fmt.Errorf("IN lists on %s are not supported with summarize = true, filter on a single value instead", column)
+		}
+	}
+	return nil
+}
+
+// getPolicyStateSingleQualValue returns the value of the '=' qual on the column if it has a single value
+func getPolicyStateSingleQualValue(d *plugin.QueryData, column string) *string {
+	if d.EqualsQuals[column] == nil || d.EqualsQuals[column].GetListValue() != nil {
+		return nil
+	}
+	v := d.EqualsQuals[column].GetStringValue()
+	return &v
+}
+
+// buildPolicyStateFilter builds the OData filter from the quals on the filterable columns.
+// The values of IN lists are combined with 'or', and the columns are combined with 'and'.
+func buildPolicyStateFilter(d *plugin.QueryData) string {
+	var filters []string
+	for _, column := range []string{"policy_assignment_id", "resource_type", "compliance_state", "resource_group"} {
+		if d.Quals[column] == nil {
+			continue
+		}
+		for _, q := range d.Quals[column].Quals {
+			if q.Operator != "=" || q.Value == nil {
+				continue
+			}
+			values := []string{q.Value.GetStringValue()}
+			if listValue := q.Value.GetListValue(); listValue != nil {
+				values = nil
+				for _, v := range listValue.Values {
+					values = append(values, v.GetStringValue())
+				}
+			}
+
+			var conditions []string
+			for _, v := range values {
+				conditions = append(conditions, fmt.Sprintf("%s eq '%s'", policyStateFilterFields[column], strings.ReplaceAll(v, "'", "''")))
+			}
+			if len(conditions) == 1 {
+				filters = append(filters, conditions[0])
+			} else if len(conditions) > 1 {
+				filters = append(filters, "("+strings.Join(conditions, " or ")+")")
+			}
+		}
+	}
+	return strings.Join(filters, " and ")
+}
+
+// getPolicyStateTimeRange returns the time range of the policy states from the quals on the timestamp column.
+// Policy Insights defaults to the day before the query if no time range is given.
+func getPolicyStateTimeRange(d *plugin.QueryData) (*date.Time, *date.Time) {
+	var from, to *date.Time
+	if d.Quals["timestamp"] == nil {
+		return from, to
+	}
+	for _, q := range d.Quals["timestamp"].Quals {
+		t := q.Value.GetTimestampValue().AsTime()
+		switch q.Operator {
+		case ">", ">=":
+			from = &date.Time{Time: t}
+		case "<", "<=":
+			to = &date.Time{Time: t}
+		}
+	}
+	return from, to
+}
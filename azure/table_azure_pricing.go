@@ -0,0 +1,328 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	retailPricesEndpoint   = "https://prices.azure.com/api/retail/prices"
+	retailPricesAPIVersion = "2023-01-01-preview"
+
+	// Retail prices rarely change, so pages are kept in the connection cache for a day
+	retailPricesCacheTTL = 24 * time.Hour
+)
+
+// RetailPrice is an item returned by the Azure retail prices API
+type RetailPrice struct {
+	CurrencyCode         string                   `json:"currencyCode"`
+	TierMinimumUnits     float64                  `json:"tierMinimumUnits"`
+	RetailPrice          float64                  `json:"retailPrice"`
+	UnitPrice            float64                  `json:"unitPrice"`
+	ArmRegionName        string                   `json:"armRegionName"`
+	Location             string                   `json:"location"`
+	EffectiveStartDate   *time.Time               `json:"effectiveStartDate"`
+	EffectiveEndDate     *time.Time               `json:"effectiveEndDate"`
+	MeterID              string                   `json:"meterId"`
+	MeterName            string                   `json:"meterName"`
+	ProductID            string                   `json:"productId"`
+	ProductName          string                   `json:"productName"`
+	SkuID                string                   `json:"skuId"`
+	SkuName              string                   `json:"skuName"`
+	ArmSkuName           string                   `json:"armSkuName"`
+	ServiceID            string                   `json:"serviceId"`
+	ServiceName          string                   `json:"serviceName"`
+	ServiceFamily        string                   `json:"serviceFamily"`
+	UnitOfMeasure        string                   `json:"unitOfMeasure"`
+	Type                 string                   `json:"type"`
+	IsPrimaryMeterRegion bool                     `json:"isPrimaryMeterRegion"`
+	ReservationTerm      string                   `json:"reservationTerm"`
+	SavingsPlan          []map[string]interface{} `json:"savingsPlan"`
+}
+
+// retailPricesPage is a page of results returned by the Azure retail prices API
+type retailPricesPage struct {
+	BillingCurrency string        `json:"BillingCurrency"`
+	Items           []RetailPrice `json:"Items"`
+	NextPageLink    string        `json:"NextPageLink"`
+	Count           int           `json:"Count"`
+}
+
+//// TABLE DEFINITION
+
+func tableAzurePricing(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_pricing",
+		Description: "Azure Pricing",
+		List: &plugin.ListConfig{
+			Hydrate: listAzurePrices,
+			Tags: map[string]string{
+				"service": "Microsoft.Commerce",
+				"action":  "retailPrices/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "service_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "arm_region_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "arm_sku_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "meter_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "price_type",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "currency_code",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "service_name",
+				Description: "The name of the service, e.g. Virtual Machines.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_id",
+				Description: "The ID of the service.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ServiceID"),
+			},
+			{
+				Name:        "service_family",
+				Description: "The service family, e.g. Compute.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "product_name",
+				Description: "The name of the product.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "product_id",
+				Description: "The ID of the product.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProductID"),
+			},
+			{
+				Name:        "sku_name",
+				Description: "The name of the SKU.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "sku_id",
+				Description: "The ID of the SKU.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SkuID"),
+			},
+			{
+				Name:        "arm_sku_name",
+				Description: "The SKU name registered in Azure Resource Manager, e.g. Standard_D2s_v3.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "meter_name",
+				Description: "The name of the meter.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "meter_id",
+				Description: "The ID of the meter.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MeterID"),
+			},
+			{
+				Name:        "price_type",
+				Description: "The type of price, e.g. Consumption, Reservation or DevTestConsumption.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Type"),
+			},
+			{
+				Name:        "arm_region_name",
+				Description: "The region name registered in Azure Resource Manager, e.g. eastus.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "location",
+				Description: "The display name of the region, e.g. US East.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "currency_code",
+				Description: "The currency of the prices. Defaults to USD if not specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "retail_price",
+				Description: "The retail price without any discount.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "unit_price",
+				Description: "The price per unit of measure.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "unit_of_measure",
+				Description: "The unit of measure the price applies to, e.g. 1 Hour.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tier_minimum_units",
+				Description: "The minimum number of units of the price tier.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "reservation_term",
+				Description: "The term of the reservation, for reservation prices.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_primary_meter_region",
+				Description: "Indicates whether the region is the primary region of the meter.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "effective_start_date",
+				Description: "The date from which the price is effective.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "effective_end_date",
+				Description: "The date until which the price is effective.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "savings_plan",
+				Description: "The savings plan prices of the meter, per term.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MeterName"),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listAzurePrices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// The retail prices API is public, so the pipeline has no authentication policy
+	retryRules := getRetryRules(d.Connection)
+	pipeline := runtime.NewPipeline("steampipe-plugin-azure", "v1", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Retry: policy.RetryOptions{
+			MaxRetries: int32(*retryRules.MaxErrorRetryAttempts),
+			RetryDelay: *retryRules.MinErrorRetryDelay,
+		},
+	})
+
+	nextLink := buildRetailPricesURL(d)
+	for nextLink != "" {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := getRetailPricesPage(ctx, d, pipeline, nextLink)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_pricing.listAzurePrices", "api_error", err)
+			return nil, err
+		}
+
+		for _, price := range page.Items {
+			d.StreamListItem(ctx, price)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		nextLink = page.NextPageLink
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// buildRetailPricesURL builds the URL of the first page, with the quals pushed down into the OData filter
+func buildRetailPricesURL(d *plugin.QueryData) string {
+	filterFields := []struct{ column, field string }{
+		{"service_name", "serviceName"},
+		{"arm_region_name", "armRegionName"},
+		{"arm_sku_name", "armSkuName"},
+		{"meter_name", "meterName"},
+		{"price_type", "priceType"},
+	}
+
+	var filters []string
+	for _, f := range filterFields {
+		if value := d.EqualsQualString(f.column); value != "" {
+			filters = append(filters, fmt.Sprintf("%s eq '%s'", f.field, strings.ReplaceAll(value, "'", "''")))
+		}
+	}
+
+	params := url.Values{}
+	params.Set("api-version", retailPricesAPIVersion)
+	if currencyCode := d.EqualsQualString("currency_code"); currencyCode != "" {
+		params.Set("currencyCode", currencyCode)
+	}
+	if len(filters) > 0 {
+		params.Set("$filter", strings.Join(filters, " and "))
+	}
+
+	return retailPricesEndpoint + "?" + params.Encode()
+}
+
+// getRetailPricesPage returns a page of retail prices, from the connection cache if it was fetched before
+func getRetailPricesPage(ctx context.Context, d *plugin.QueryData, pipeline runtime.Pipeline, pageURL string) (*retailPricesPage, error) {
+	cacheKey := "azure_pricing" + pageURL
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*retailPricesPage), nil
+	}
+
+	req, err := runtime.NewRequest(ctx, http.MethodGet, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := pipeline.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return nil, runtime.NewResponseError(resp)
+	}
+
+	page := &retailPricesPage{}
+	if err := runtime.UnmarshalAsJSON(resp, page); err != nil {
+		return nil, err
+	}
+
+	d.ConnectionManager.Cache.SetWithTTL(cacheKey, page, retailPricesCacheTTL)
+	return page, nil
+}
@@ -0,0 +1,358 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/reservations/mgmt/reservations"
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureReservation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_reservation",
+		Description: "Azure Reservation",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"reservation_order_id", "reservation_id"}),
+			Hydrate:    getReservation,
+			Tags: map[string]string{
+				"service": "Microsoft.Capacity",
+				"action":  "reservationOrders/reservations/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "ReservationNotFound", "404"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listReservations,
+			Tags: map[string]string{
+				"service": "Microsoft.Capacity",
+				"action":  "reservations/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the reservation, in the form {reservation_order_id}/{reservation_id}.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the reservation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "reservation_id",
+				Description: "The ID of the reservation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(lastPathElement),
+			},
+			{
+				Name:        "reservation_order_id",
+				Description: "The ID of the reservation order the reservation belongs to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(reservationOrderIDFromResourceID),
+			},
+			{
+				Name:        "display_name",
+				Description: "The friendly name of the reservation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The kind of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "sku_name",
+				Description: "The SKU the reservation is purchased for, e.g. Standard_D2s_v3.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Sku.Name"),
+			},
+			{
+				Name:        "sku_description",
+				Description: "The description of the SKU in English.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.SkuDescription"),
+			},
+			{
+				Name:        "reserved_resource_type",
+				Description: "The type of the resource that is being reserved, e.g. VirtualMachines or SqlDatabases.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ReservedResourceType"),
+			},
+			{
+				Name:        "quantity",
+				Description: "The quantity of the SKUs that are part of the reservation.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.Quantity"),
+			},
+			{
+				Name:        "term",
+				Description: "The term of the reservation, e.g. P1Y or P3Y.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Term"),
+			},
+			{
+				Name:        "billing_plan",
+				Description: "Indicates whether the reservation is billed Upfront or Monthly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BillingPlan"),
+			},
+			{
+				Name:        "billing_scope_id",
+				Description: "The subscription that is billed for the reservation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BillingScopeID"),
+			},
+			{
+				Name:        "applied_scope_type",
+				Description: "The type of the applied scope, either Single or Shared.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AppliedScopeType"),
+			},
+			{
+				Name:        "applied_scopes",
+				Description: "The list of scopes the reservation benefit is applied to, if the applied scope type is Single.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.AppliedScopes"),
+			},
+			{
+				Name:        "instance_flexibility",
+				Description: "Indicates whether the reservation benefit applies to other VM sizes in the same group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.InstanceFlexibility"),
+			},
+			{
+				Name:        "provisioning_state",
+				Description: "The current provisioning state of the reservation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ProvisioningState"),
+			},
+			{
+				Name:        "display_provisioning_state",
+				Description: "The provisioning state of the reservation as displayed in the portal, e.g. Succeeded, Expiring or Expired.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayProvisioningState"),
+			},
+			{
+				Name:        "archived",
+				Description: "Indicates whether the reservation is archived.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.Archived"),
+			},
+			{
+				Name:        "purchase_date",
+				Description: "The date the reservation was purchased.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.PurchaseDate").Transform(convertDateOnlyToTime),
+			},
+			{
+				Name:        "effective_date_time",
+				Description: "The date time the reservation became effective.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.EffectiveDateTime").Transform(convertDateToTime),
+			},
+			{
+				Name:        "benefit_start_time",
+				Description: "The time the reservation benefit started.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.BenefitStartTime").Transform(convertDateToTime),
+			},
+			{
+				Name:        "expiry_date",
+				Description: "The date the reservation expires.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.ExpiryDate").Transform(convertDateOnlyToTime),
+			},
+			{
+				Name:        "last_updated_date_time",
+				Description: "The time the reservation was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.LastUpdatedDateTime").Transform(convertDateToTime),
+			},
+			{
+				Name:        "renew",
+				Description: "Indicates whether the reservation is automatically renewed on expiry.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.Renew"),
+			},
+			{
+				Name:        "user_friendly_renew_state",
+				Description: "The renew state of the reservation as displayed in the portal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.UserFriendlyRenewState"),
+			},
+			{
+				Name:        "utilization_trend",
+				Description: "The trend of the utilization of the reservation, e.g. UP, DOWN or SAME.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Utilization.Trend"),
+			},
+			{
+				Name:        "utilization_aggregates",
+				Description: "The average utilization of the reservation over the last 1, 7 and 30 days.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Utilization.Aggregates"),
+			},
+			{
+				Name:        "extended_status_info",
+				Description: "Additional information about the status of the reservation.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ExtendedStatusInfo"),
+			},
+			{
+				Name:        "renew_properties",
+				Description: "The purchase properties of the renewal of the reservation.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.RenewProperties"),
+			},
+			{
+				Name:        "system_data",
+				Description: "Metadata pertaining to creation and last modification of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Location").Transform(toLower),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listReservations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation.listReservations", "session_error", err)
+		return nil, err
+	}
+
+	client := reservations.NewClientWithBaseURI(session.ResourceManagerEndpoint)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	result, err := client.ListAll(ctx, "", "", "", nil, "", nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation.listReservations", "api_error", err)
+		return nil, err
+	}
+
+	for _, reservation := range result.Values() {
+		d.StreamListItem(ctx, reservation)
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	for result.NotDone() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_reservation.listReservations", "paging_error", err)
+			return nil, err
+		}
+
+		for _, reservation := range result.Values() {
+			d.StreamListItem(ctx, reservation)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getReservation(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	reservationOrderID := d.EqualsQualString("reservation_order_id")
+	reservationID := d.EqualsQualString("reservation_id")
+	if reservationOrderID == "" || reservationID == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation.getReservation", "session_error", err)
+		return nil, err
+	}
+
+	client := reservations.NewClientWithBaseURI(session.ResourceManagerEndpoint)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	op, err := client.Get(ctx, reservationID, reservationOrderID, "")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation.getReservation", "api_error", err)
+		return nil, err
+	}
+
+	// In some cases the API does not return any notFound error
+	// instead it returns empty data
+	if op.ID != nil {
+		return op, nil
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// reservationOrderIDFromResourceID extracts the reservation order ID from a reservation resource ID,
+// e.g. /providers/Microsoft.Capacity/reservationOrders/{reservationOrderId}/reservations/{reservationId}
+func reservationOrderIDFromResourceID(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	return getReservationOrderID(types.SafeString(d.Value)), nil
+}
+
+func getReservationOrderID(id string) string {
+	parts := strings.Split(id, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if strings.EqualFold(parts[i], "reservationOrders") {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,379 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/consumption/mgmt/consumption"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// ReservationRecommendationRow is a reservation purchase recommendation, normalized from the legacy and modern recommendation kinds
+type ReservationRecommendationRow struct {
+	ID                             *string
+	Name                           *string
+	Type                           *string
+	Kind                           string
+	Scope                          string
+	Location                       *string
+	Sku                            *string
+	SubscriptionID                 *string
+	RecommendationScope            *string
+	LookBackPeriod                 *string
+	Term                           *string
+	ResourceType                   *string
+	RecommendedQuantity            *float64
+	RecommendedQuantityNormalized  *float64
+	NormalizedSize                 *string
+	InstanceFlexibilityGroup       *string
+	InstanceFlexibilityRatio       *float64
+	CostWithNoReservedInstances    *float64
+	TotalCostWithReservedInstances *float64
+	NetSavings                     *float64
+	Currency                       *string
+	FirstUsageDate                 *time.Time
+	MeterID                        *string
+	SkuProperties                  *[]consumption.SkuProperty
+}
+
+//// TABLE DEFINITION
+
+func tableAzureReservationRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_reservation_recommendation",
+		Description: "Azure Reservation Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listReservationRecommendations,
+			Tags: map[string]string{
+				"service": "Microsoft.Consumption",
+				"action":  "reservationRecommendations/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "recommendation_scope",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "look_back_period",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "resource_type",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the recommendation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the recommendation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kind",
+				Description: "The kind of the recommendation, either legacy or modern.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope",
+				Description: "The scope the recommendations are listed for. Defaults to the current subscription if not specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "recommendation_scope",
+				Description: "The scope of the recommended reservation, either Single or Shared. The API defaults to Single if not specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "look_back_period",
+				Description: "The number of days of usage the recommendation is based on, e.g. Last7Days, Last30Days or Last60Days. The API defaults to Last7Days if not specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of resource the recommendation is for, e.g. VirtualMachines, SQLDatabases or CosmosDB. The API defaults to VirtualMachines if not specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "sku",
+				Description: "The SKU of the recommended reservation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "term",
+				Description: "The term of the recommended reservation, e.g. P1Y or P3Y.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "recommended_quantity",
+				Description: "The recommended number of reserved instances to purchase.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "recommended_quantity_normalized",
+				Description: "The recommended quantity, normalized to the smallest size in the instance flexibility group.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "normalized_size",
+				Description: "The normalized size of the recommendation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "instance_flexibility_group",
+				Description: "The instance flexibility group of the recommended SKU.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "instance_flexibility_ratio",
+				Description: "The instance flexibility ratio of the recommended SKU.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "cost_with_no_reserved_instances",
+				Description: "The cost of the usage over the look back period without reserved instances.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "total_cost_with_reserved_instances",
+				Description: "The cost of the usage over the look back period with the recommended reserved instances.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "net_savings",
+				Description: "The savings over the look back period if the recommended reservation had been purchased.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "currency",
+				Description: "The currency of the cost and savings amounts. Only returned for modern recommendations.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "first_usage_date",
+				Description: "The date of the first usage in the look back period.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "meter_id",
+				Description: "The ID of the meter the recommendation is based on.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MeterID"),
+			},
+			{
+				Name:        "recommendation_subscription_id",
+				Description: "The subscription the recommendation applies to, for single scope recommendations.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SubscriptionID"),
+			},
+			{
+				Name:        "sku_properties",
+				Description: "The properties of the recommended SKU.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: ColumnDescriptionRegion,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Location").Transform(toLower),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listReservationRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	scope, err := getCostManagementScope(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation_recommendation.listReservationRecommendations", "scope_error", err)
+		return nil, err
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation_recommendation.listReservationRecommendations", "session_error", err)
+		return nil, err
+	}
+
+	client := consumption.NewReservationRecommendationsClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	filterColumns := map[string]string{
+		"recommendation_scope": "properties/scope",
+		"look_back_period":     "properties/lookBackPeriod",
+		"resource_type":        "properties/resourceType",
+	}
+	var filters []string
+	for _, column := range []string{"recommendation_scope", "look_back_period", "resource_type"} {
+		if value := d.EqualsQualString(column); value != "" {
+			filters = append(filters, fmt.Sprintf("%s eq '%s'", filterColumns[column], strings.ReplaceAll(value, "'", "''")))
+		}
+	}
+
+	result, err := client.List(ctx, scope, strings.Join(filters, " AND "))
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation_recommendation.listReservationRecommendations", "api_error", err)
+		return nil, err
+	}
+
+	for {
+		for _, recommendation := range result.Values() {
+			row := buildReservationRecommendationRow(d, recommendation, scope)
+			if row == nil {
+				continue
+			}
+			d.StreamListItem(ctx, row)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if !result.NotDone() {
+			break
+		}
+
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_reservation_recommendation.listReservationRecommendations", "paging_error", err)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// buildReservationRecommendationRow normalizes a legacy or modern reservation recommendation into a row
+func buildReservationRecommendationRow(d *plugin.QueryData, recommendation consumption.BasicReservationRecommendation, scope string) *ReservationRecommendationRow {
+	row := &ReservationRecommendationRow{Scope: scope}
+
+	if legacy, ok := recommendation.AsLegacyReservationRecommendation(); ok {
+		row.ID, row.Name, row.Type, row.Kind = legacy.ID, legacy.Name, legacy.Type, string(legacy.Kind)
+		row.Location, row.Sku = legacy.Location, legacy.Sku
+		if p := legacy.LegacyReservationRecommendationProperties; p != nil {
+			row.RecommendationScope = p.Scope
+			row.LookBackPeriod = p.LookBackPeriod
+			row.Term = p.Term
+			row.ResourceType = p.ResourceType
+			row.RecommendedQuantity = decimalPtrToFloat64(p.RecommendedQuantity)
+			row.RecommendedQuantityNormalized = p.RecommendedQuantityNormalized
+			row.NormalizedSize = p.NormalizedSize
+			row.InstanceFlexibilityGroup = p.InstanceFlexibilityGroup
+			row.InstanceFlexibilityRatio = p.InstanceFlexibilityRatio
+			row.CostWithNoReservedInstances = decimalPtrToFloat64(p.CostWithNoReservedInstances)
+			row.TotalCostWithReservedInstances = decimalPtrToFloat64(p.TotalCostWithReservedInstances)
+			row.NetSavings = decimalPtrToFloat64(p.NetSavings)
+			if p.FirstUsageDate != nil {
+				row.FirstUsageDate = &p.FirstUsageDate.Time
+			}
+			if p.MeterID != nil {
+				meterID := p.MeterID.String()
+				row.MeterID = &meterID
+			}
+			row.SkuProperties = p.SkuProperties
+		}
+	} else if modern, ok := recommendation.AsModernReservationRecommendation(); ok {
+		row.ID, row.Name, row.Type, row.Kind = modern.ID, modern.Name, modern.Type, string(modern.Kind)
+		row.Location, row.Sku = modern.Location, modern.Sku
+		if p := modern.ModernReservationRecommendationProperties; p != nil {
+			if p.Location != nil {
+				row.Location = p.Location
+			}
+			if p.SkuName != nil {
+				row.Sku = p.SkuName
+			}
+			row.SubscriptionID = p.SubscriptionID
+			row.RecommendationScope = p.Scope
+			if p.LookBackPeriod != nil {
+				lookBackPeriod := fmt.Sprintf("Last%dDays", *p.LookBackPeriod)
+				row.LookBackPeriod = &lookBackPeriod
+			}
+			row.Term = p.Term
+			row.ResourceType = p.ResourceType
+			row.RecommendedQuantity = decimalPtrToFloat64(p.RecommendedQuantity)
+			row.RecommendedQuantityNormalized = p.RecommendedQuantityNormalized
+			row.NormalizedSize = p.NormalizedSize
+			row.InstanceFlexibilityGroup = p.InstanceFlexibilityGroup
+			row.InstanceFlexibilityRatio = p.InstanceFlexibilityRatio
+			for _, amount := range []struct {
+				source *consumption.Amount
+				target **float64
+			}{
+				{p.CostWithNoReservedInstances, &row.CostWithNoReservedInstances},
+				{p.TotalCostWithReservedInstances, &row.TotalCostWithReservedInstances},
+				{p.NetSavings, &row.NetSavings},
+			} {
+				if amount.source == nil {
+					continue
+				}
+				*amount.target = decimalPtrToFloat64(amount.source.Value)
+				if amount.source.Currency != nil {
+					row.Currency = amount.source.Currency
+				}
+			}
+			if p.FirstUsageDate != nil {
+				row.FirstUsageDate = &p.FirstUsageDate.Time
+			}
+			if p.MeterID != nil {
+				meterID := p.MeterID.String()
+				row.MeterID = &meterID
+			}
+			row.SkuProperties = p.SkuProperties
+		}
+	} else {
+		return nil
+	}
+
+	// The API filters on these properties case-insensitively, so report the requested value
+	// to make sure the rows match the quals
+	for column, field := range map[string]**string{
+		"recommendation_scope": &row.RecommendationScope,
+		"look_back_period":     &row.LookBackPeriod,
+		"resource_type":        &row.ResourceType,
+	} {
+		if value := d.EqualsQualString(column); value != "" {
+			*field = &value
+		}
+	}
+
+	return row
+}
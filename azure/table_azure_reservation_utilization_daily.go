@@ -0,0 +1,251 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/consumption/mgmt/consumption"
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/reservations/mgmt/reservations"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureReservationUtilizationDaily(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_reservation_utilization_daily",
+		Description: "Azure Reservation Utilization Daily",
+		List: &plugin.ListConfig{
+			ParentHydrate: listReservations,
+			Hydrate:       listReservationUtilizationDaily,
+			Tags: map[string]string{
+				"service": "Microsoft.Consumption",
+				"action":  "reservationSummaries/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "reservation_order_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "reservation_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:       "usage_date",
+					Require:    plugin.Optional,
+					Operators:  []string{"=", ">", ">=", "<", "<="},
+					CacheMatch: query_cache.CacheMatchExact,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "reservation_order_id",
+				Description: "The ID of the reservation order.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReservationSummaryProperties.ReservationOrderID"),
+			},
+			{
+				Name:        "reservation_id",
+				Description: "The ID of the reservation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReservationSummaryProperties.ReservationID"),
+			},
+			{
+				Name:        "usage_date",
+				Description: "The day the utilization is reported for. Defaults to the last 30 days if not specified in the where clause.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("ReservationSummaryProperties.UsageDate").Transform(convertDateToTime),
+			},
+			{
+				Name:        "sku_name",
+				Description: "The SKU of the reservation, e.g. Standard_D2s_v3.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReservationSummaryProperties.SkuName"),
+			},
+			{
+				Name:        "kind",
+				Description: "The kind of the reservation, e.g. Reservation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReservationSummaryProperties.Kind"),
+			},
+			{
+				Name:        "reserved_hours",
+				Description: "The total hours reserved for the day, e.g. 24 hours for each reserved instance.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.ReservedHours").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "used_hours",
+				Description: "The total hours used by the reserved instances during the day.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.UsedHours").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "utilized_percentage",
+				Description: "The percentage of the reservation that was used during the day.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.UtilizedPercentage").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "min_utilization_percentage",
+				Description: "The minimum hourly utilization percentage during the day.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.MinUtilizationPercentage").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "avg_utilization_percentage",
+				Description: "The average hourly utilization percentage during the day.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.AvgUtilizationPercentage").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "max_utilization_percentage",
+				Description: "The maximum hourly utilization percentage during the day.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.MaxUtilizationPercentage").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "purchased_quantity",
+				Description: "The purchased quantity of the reservation.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.PurchasedQuantity").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "remaining_quantity",
+				Description: "The remaining quantity of the reservation.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.RemainingQuantity").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "total_reserved_quantity",
+				Description: "The total reserved quantity of the reservation.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.TotalReservedQuantity").Transform(decimalToFloat64),
+			},
+			{
+				Name:        "used_quantity",
+				Description: "The used quantity of the reservation.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("ReservationSummaryProperties.UsedQuantity").Transform(decimalToFloat64),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listReservationUtilizationDaily(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	reservation := h.Item.(reservations.Response)
+	if reservation.ID == nil {
+		return nil, nil
+	}
+	reservationOrderID := getReservationOrderID(*reservation.ID)
+	reservationID := getLastPathElement(*reservation.ID)
+
+	// Only list the utilization of the requested reservations
+	if d.EqualsQualString("reservation_order_id") != "" && d.EqualsQualString("reservation_order_id") != reservationOrderID {
+		return nil, nil
+	}
+	if d.EqualsQualString("reservation_id") != "" && d.EqualsQualString("reservation_id") != reservationID {
+		return nil, nil
+	}
+
+	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation_utilization_daily.listReservationUtilizationDaily", "session_error", err)
+		return nil, err
+	}
+
+	client := consumption.NewReservationsSummariesClientWithBaseURI(session.ResourceManagerEndpoint, session.SubscriptionID)
+	client.Authorizer = session.Authorizer
+
+	// Apply Retry rule
+	ApplyRetryRules(ctx, &client, d.Connection)
+
+	// The usage date filter is required for the daily grain
+	start, end := getUsageDateRange(d, 30)
+	filter := fmt.Sprintf("properties/usageDate ge %s AND properties/usageDate le %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+
+	result, err := client.ListByReservationOrderAndReservation(ctx, reservationOrderID, reservationID, consumption.DatagrainDailyGrain, filter)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_reservation_utilization_daily.listReservationUtilizationDaily", "api_error", err)
+		return nil, err
+	}
+
+	for _, summary := range result.Values() {
+		d.StreamListItem(ctx, summary)
+		// Check if context has been cancelled or if the limit has been hit (if specified)
+		// if there is a limit, it will return the number of rows required to reach this limit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	for result.NotDone() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		err = result.NextWithContext(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_reservation_utilization_daily.listReservationUtilizationDaily", "paging_error", err)
+			return nil, err
+		}
+
+		for _, summary := range result.Values() {
+			d.StreamListItem(ctx, summary)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// getUsageDateRange derives the days to query from the quals on the usage_date column.
+// Defaults to the given number of days up to yesterday.
+func getUsageDateRange(d *plugin.QueryData, defaultDays int) (time.Time, time.Time) {
+	day := 24 * time.Hour
+	var start, end time.Time
+
+	if d.Quals["usage_date"] != nil {
+		for _, q := range d.Quals["usage_date"].Quals {
+			t := q.Value.GetTimestampValue().AsTime().UTC()
+			switch q.Operator {
+			case "=":
+				start, end = t.Truncate(day), t.Truncate(day)
+			case ">=":
+				start = t.Truncate(day)
+			case ">":
+				start = t.Truncate(day).Add(day)
+			case "<=":
+				end = t.Truncate(day)
+			case "<":
+				if t.Equal(t.Truncate(day)) {
+					end = t.Add(-day)
+				} else {
+					end = t.Truncate(day)
+				}
+			}
+		}
+	}
+
+	if end.IsZero() {
+		end = time.Now().UTC().AddDate(0, 0, -1).Truncate(day)
+	}
+	if start.IsZero() {
+		start = end.AddDate(0, 0, -defaultDays+1)
+	}
+
+	return start, end
+}
@@ -2,6 +2,8 @@ package azure
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
 
@@ -11,6 +13,15 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
+// RoleAssignmentInfo is a role assignment, with the scopes reported with the casing used in the quals
+type RoleAssignmentInfo struct {
+	armauthorization.RoleAssignment
+	Scope            *string
+	EffectiveScope   *string
+	IncludeInherited bool
+	InheritedFrom    *string
+}
+
 //// TABLE DEFINITION
 
 func tableAzureIamRoleAssignment(_ context.Context) *plugin.Table {
@@ -34,29 +45,25 @@ func tableAzureIamRoleAssignment(_ context.Context) *plugin.Table {
 				"service": "Microsoft.Authorization",
 				"action":  "roleAssignments/read",
 			},
-			// For the time being, the optional qualifiers have been commented out
-			// due to an issue with the Azure REST API that generates the following error:
-			// 	{
-			//   "error": {
-			//     "code": "UnsupportedQuery",
-			//     "message": "The filter 'principalId' is not supported. Supported filters are either 'atScope()' or 'principalId eq '{value}' or assignedTo('{value}')'."
-			//   }
-			// }
-			// Ref: https://github.com/Azure/azure-rest-api-specs/issues/28255
-			// We will uncomment it once the issue is resolved.
-
 			KeyColumns: []*plugin.KeyColumn{
-				// When specifying the `scope` value as a query parameter in the WHERE clause,
-				// ensure that it begins with a "/" (forward slash).
-				// If omitted, the query will return empty results due to Steampipe level filtering.
-				// This is because the API response includes a leading "/" in the scope values.
-				// Example values:
-				// - "/subscriptions/<sub_id>"
-				// - "/subscriptions/<sub_id>/resourceGroups/<rg_name>"
+				// The scope may be given with or without the leading "/" (forward slash), in any casing,
+				// e.g. "/subscriptions/<sub_id>" or "subscriptions/<sub_id>/resourceGroups/<rg_name>"
 				{
 					Name:    "scope",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "effective_scope",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "principal_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "include_inherited",
+					Require: plugin.Optional,
+				},
 			},
 		},
 		Columns: azureColumns([]*plugin.Column{
@@ -73,9 +80,24 @@ func tableAzureIamRoleAssignment(_ context.Context) *plugin.Table {
 			},
 			{
 				Name:        "scope",
-				Description: "Current state of the role assignment.",
+				Description: "The scope of the role assignment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Scope"),
+			},
+			{
+				Name:        "effective_scope",
+				Description: "The scope the role assignment is effective at, i.e. the queried effective_scope or scope. Without a scope qual, role assignments above the subscription are effective at the subscription.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "include_inherited",
+				Description: "If true, role assignments inherited from the parent scopes of the queried effective_scope are also returned. If false, role assignments above the subscription are not returned when no scope is given.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "inherited_from",
+				Description: "The parent scope the role assignment is inherited from, i.e. its scope if it is assigned above the queried scope, or above the subscription if no scope is given.",
 				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromField("Properties.Scope"),
 			},
 			{
 				Name:        "type",
@@ -144,47 +166,42 @@ func listIamRoleAssignments(ctx context.Context, d *plugin.QueryData, h *plugin.
 	}
 
 	// Check if a specific scope is provided
-	if d.EqualsQualString("scope") != "" {
+	if d.EqualsQualString("scope") != "" || d.EqualsQualString("effective_scope") != "" {
 		return listRoleAssignmentsByScope(ctx, d, h, authorizationClient)
 	}
 
 	// If no scope is provided, fetch all role assignments for the subscription
-	return listAllRoleAssignments(ctx, d, h, authorizationClient)
+	return listAllRoleAssignments(ctx, d, h, authorizationClient, session.SubscriptionID)
 }
 
 // listRoleAssignmentsByScope retrieves role assignments for a specific scope
 func listRoleAssignmentsByScope(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData, authorizationClient *armauthorization.RoleAssignmentsClient) (interface{}, error) {
 	scope := d.EqualsQualString("scope")
+	effectiveScope := d.EqualsQualString("effective_scope")
+	listScope := effectiveScope
+	if listScope == "" {
+		listScope = scope
+	}
 
-	defaultFilter := "atScope()" // filter all result
+	// atScope() returns the role assignments at or above the scope, the latter being dropped unless include_inherited is set
+	// together with effective_scope, since the scope of an inherited assignment differs from the scope qual.
+	// The API does not support 'principalId eq' together with atScope(), but supports assignedTo().
+	// Ref: https://github.com/Azure/azure-rest-api-specs/issues/28255
+	filter := "atScope()"
+	if principalFilter := getRoleAssignmentPrincipalFilter(d); principalFilter != "" {
+		filter = filter + " and " + principalFilter
+	}
 	// Tenant ID is not a required parameter to make the API call.
 	// List by scope input
 	listForScopeOptions := &armauthorization.RoleAssignmentsClientListForScopeOptions{
-		Filter: &defaultFilter,
+		Filter: &filter,
 	}
 
-	// For the time being, the optional qualifiers have been commented out
-	// due to an issue with the Azure REST API that generates the following error:
-	// 	{
-	//   "error": {
-	//     "code": "UnsupportedQuery",
-	//     "message": "The filter 'principalId' is not supported. Supported filters are either 'atScope()' or 'principalId eq '{value}' or assignedTo('{value}')'."
-	//   }
-	// }
-	// Ref: https://github.com/Azure/azure-rest-api-specs/issues/28255
-	// We will uncomment it once the issue is resolved.
-
-	// var filter string
-	// if d.EqualsQuals["principal_id"] != nil {
-	// 	filter = fmt.Sprintf("principalId eq '%s'", d.EqualsQuals["principal_id"].GetStringValue())
-	// }
-
-	// if filter != "" {
-	// 	option.Filter = &filter
-	// }
-
-	response := authorizationClient.NewListForScopePager(scope, listForScopeOptions)
+	response := authorizationClient.NewListForScopePager(strings.TrimPrefix(normalizeRoleAssignmentScope(listScope), "/"), listForScopeOptions)
 	for response.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
 		scopeRes, err := response.NextPage(ctx)
 		if err != nil {
 			plugin.Logger(ctx).Error("azure_role_assignment.listRoleAssignmentsByScope", "api_error", err)
@@ -192,7 +209,25 @@ func listRoleAssignmentsByScope(ctx context.Context, d *plugin.QueryData, _ *plu
 		}
 
 		for _, roleAssignment := range scopeRes.Value {
-			d.StreamListItem(ctx, roleAssignment)
+			item, ok := roleAssignmentInfo(d, roleAssignment)
+			if !ok {
+				continue
+			}
+
+			if item.Scope == nil || !isSameRoleAssignmentScope(*item.Scope, listScope) {
+				if !item.IncludeInherited || effectiveScope == "" {
+					continue
+				}
+				item.InheritedFrom = item.Scope
+			}
+
+			// Report the scopes as queried, so that the assignment is not dropped for differing in casing or in the leading slash
+			if scope != "" && item.Scope != nil && isSameRoleAssignmentScope(*item.Scope, scope) {
+				item.Scope = &scope
+			}
+			item.EffectiveScope = &listScope
+
+			d.StreamListItem(ctx, item)
 
 			// Check if context has been cancelled or if the limit has been hit (if specified)
 			// if there is a limit, it will return the number of rows required to reach this limit
@@ -206,9 +241,14 @@ func listRoleAssignmentsByScope(ctx context.Context, d *plugin.QueryData, _ *plu
 }
 
 // listAllRoleAssignments retrieves all role assignments for the subscription
-func listAllRoleAssignments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData, authorizationClient *armauthorization.RoleAssignmentsClient) (interface{}, error) {
+func listAllRoleAssignments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData, authorizationClient *armauthorization.RoleAssignmentsClient, subscriptionID string) (interface{}, error) {
+	listOptions := &armauthorization.RoleAssignmentsClientListForSubscriptionOptions{}
+	if principalFilter := getRoleAssignmentPrincipalFilter(d); principalFilter != "" {
+		listOptions.Filter = &principalFilter
+	}
 
-	result := authorizationClient.NewListForSubscriptionPager(&armauthorization.RoleAssignmentsClientListForSubscriptionOptions{})
+	result := authorizationClient.NewListForSubscriptionPager(listOptions)
+	subscriptionScope := "/subscriptions/" + subscriptionID
 
 	for result.More() {
 		// Wait for rate limiting
@@ -221,7 +261,21 @@ func listAllRoleAssignments(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		}
 
 		for _, roleAssignment := range res.Value {
-			d.StreamListItem(ctx, roleAssignment)
+			item, ok := roleAssignmentInfo(d, roleAssignment)
+			if !ok {
+				continue
+			}
+
+			// The role assignments of the subscription include those at management group and root scopes
+			if item.Scope != nil && !isSameOrChildRoleAssignmentScope(*item.Scope, subscriptionScope) {
+				if d.EqualsQuals["include_inherited"] != nil && !item.IncludeInherited {
+					continue
+				}
+				item.InheritedFrom = item.Scope
+				item.EffectiveScope = &subscriptionScope
+			}
+
+			d.StreamListItem(ctx, item)
 
 			// Check if context has been cancelled or if the limit has been hit (if specified)
 			// if there is a limit, it will return the number of rows required to reach this limit
@@ -258,5 +312,55 @@ func getIamRoleAssignment(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		return nil, err
 	}
 
-	return op, nil
+	item, _ := roleAssignmentInfo(d, &op.RoleAssignment)
+	return item, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// roleAssignmentInfo wraps a role assignment. Role assignments of other principals, returned by assignedTo() for the
+// groups the principal is a member of, are reported as not ok since they do not match the principal_id qual.
+func roleAssignmentInfo(d *plugin.QueryData, roleAssignment *armauthorization.RoleAssignment) (RoleAssignmentInfo, bool) {
+	item := RoleAssignmentInfo{
+		RoleAssignment:   *roleAssignment,
+		IncludeInherited: d.EqualsQuals["include_inherited"] != nil && d.EqualsQuals["include_inherited"].GetBoolValue(),
+	}
+	if roleAssignment.Properties == nil {
+		return item, true
+	}
+	item.Scope = roleAssignment.Properties.Scope
+	item.EffectiveScope = roleAssignment.Properties.Scope
+
+	principalID := d.EqualsQualString("principal_id")
+	if principalID != "" && (roleAssignment.Properties.PrincipalID == nil || !strings.EqualFold(*roleAssignment.Properties.PrincipalID, principalID)) {
+		return item, false
+	}
+
+	return item, true
+}
+
+// getRoleAssignmentPrincipalFilter returns the assignedTo() filter for the principal_id qual
+func getRoleAssignmentPrincipalFilter(d *plugin.QueryData) string {
+	principalID := d.EqualsQualString("principal_id")
+	if principalID == "" {
+		return ""
+	}
+	return fmt.Sprintf("assignedTo('%s')", strings.ReplaceAll(principalID, "'", "''"))
+}
+
+// normalizeRoleAssignmentScope returns the scope with a single leading slash and no trailing slash
+func normalizeRoleAssignmentScope(scope string) string {
+	return "/" + strings.Trim(scope, "/")
+}
+
+// isSameRoleAssignmentScope compares scopes regardless of casing and leading or trailing slashes
+func isSameRoleAssignmentScope(scope string, other string) bool {
+	return strings.EqualFold(normalizeRoleAssignmentScope(scope), normalizeRoleAssignmentScope(other))
+}
+
+// isSameOrChildRoleAssignmentScope reports whether the scope is the parent scope or one of its child scopes
+func isSameOrChildRoleAssignmentScope(scope string, parent string) bool {
+	scope = strings.ToLower(normalizeRoleAssignmentScope(scope))
+	parent = strings.ToLower(normalizeRoleAssignmentScope(parent))
+	return scope == parent || strings.HasPrefix(scope, parent+"/")
 }
@@ -0,0 +1,284 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// RoleAssignmentScheduleInstanceInfo is a role assignment schedule instance, with the scope reported with the casing used in the quals
+type RoleAssignmentScheduleInstanceInfo struct {
+	armauthorization.RoleAssignmentScheduleInstance
+	Scope *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureRoleAssignmentScheduleInstance(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_role_assignment_schedule_instance",
+		Description: "Azure Role Assignment Schedule Instance",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getRoleAssignmentScheduleInstance,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "roleAssignmentScheduleInstances/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"RoleAssignmentScheduleInstanceNotFound", "ResourceNotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listRoleAssignmentScheduleInstances,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "roleAssignmentScheduleInstances/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				// The scope may be given with or without the leading "/" (forward slash), in any casing
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "principal_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the role assignment schedule instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the role assignment schedule instance.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "principal_id",
+				Description: "The ID of the principal the role is assigned to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.PrincipalID"),
+			},
+			{
+				Name:        "principal_type",
+				Description: "The type of the principal, e.g. User, Group or ServicePrincipal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.PrincipalType"),
+			},
+			{
+				Name:        "principal_display_name",
+				Description: "The display name of the principal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ExpandedProperties.Principal.DisplayName"),
+			},
+			{
+				Name:        "role_definition_id",
+				Description: "The ID of the assigned role definition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.RoleDefinitionID"),
+			},
+			{
+				Name:        "role_name",
+				Description: "The name of the assigned role definition.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ExpandedProperties.RoleDefinition.DisplayName"),
+			},
+			{
+				Name:        "scope",
+				Description: "The scope of the role assignment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Scope"),
+			},
+			{
+				Name:        "scope_display_name",
+				Description: "The display name of the scope of the role assignment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ExpandedProperties.Scope.DisplayName"),
+			},
+			{
+				Name:        "member_type",
+				Description: "The membership type of the role assignment. Possible values are Direct, Group and Inherited.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.MemberType"),
+			},
+			{
+				Name:        "status",
+				Description: "The status of the role assignment schedule instance, e.g. Provisioned.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Status"),
+			},
+			{
+				Name:        "start_date_time",
+				Description: "The time the role assignment starts, e.g. the time of the activation.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.StartDateTime"),
+			},
+			{
+				Name:        "end_date_time",
+				Description: "The time the role assignment ends. Empty for permanent assignments.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.EndDateTime"),
+			},
+			{
+				Name:        "created_on",
+				Description: "The time the role assignment schedule was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.CreatedOn"),
+			},
+			{
+				Name:        "condition",
+				Description: "The condition on the role assignment, limiting the resources it applies to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Condition"),
+			},
+			{
+				Name:        "assignment_type",
+				Description: "The type of the role assignment. Activated for just-in-time activations of eligible roles, Assigned otherwise.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AssignmentType"),
+			},
+			{
+				Name:        "role_assignment_schedule_id",
+				Description: "The ID of the role assignment schedule the instance is created from.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.RoleAssignmentScheduleID"),
+			},
+			{
+				Name:        "origin_role_assignment_id",
+				Description: "The ID of the role assignment created for the instance.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.OriginRoleAssignmentID"),
+			},
+			{
+				Name:        "linked_role_eligibility_schedule_id",
+				Description: "The ID of the role eligibility schedule that was activated, for activated role assignments.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.LinkedRoleEligibilityScheduleID"),
+			},
+			{
+				Name:        "linked_role_eligibility_schedule_instance_id",
+				Description: "The ID of the role eligibility schedule instance that was activated, for activated role assignments.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.LinkedRoleEligibilityScheduleInstanceID"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Authorization/roleAssignmentScheduleInstances).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listRoleAssignmentScheduleInstances(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_assignment_schedule_instance.listRoleAssignmentScheduleInstances", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armauthorization.NewRoleAssignmentScheduleInstancesClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_assignment_schedule_instance.listRoleAssignmentScheduleInstances", "client_error", err)
+		return nil, err
+	}
+
+	scope := d.EqualsQualString("scope")
+	listScope, filter := getRoleScheduleInstanceListScope(d, session.SubscriptionID)
+
+	pager := client.NewListForScopePager(listScope, &armauthorization.RoleAssignmentScheduleInstancesClientListForScopeOptions{Filter: filter})
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_role_assignment_schedule_instance.listRoleAssignmentScheduleInstances", "api_error", err)
+			return nil, err
+		}
+
+		for _, instance := range page.Value {
+			item := RoleAssignmentScheduleInstanceInfo{RoleAssignmentScheduleInstance: *instance}
+			if instance.Properties != nil {
+				item.Scope = instance.Properties.Scope
+			}
+
+			if scope != "" {
+				if item.Scope == nil || !isSameRoleAssignmentScope(*item.Scope, scope) {
+					continue
+				}
+				// Report the scope as queried, so that the instance is not dropped for differing in casing or in the leading slash
+				item.Scope = &scope
+			}
+
+			d.StreamListItem(ctx, item)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getRoleAssignmentScheduleInstance(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+	scope := getAuthorizationResourceScope(id, "roleAssignmentScheduleInstances")
+	if scope == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_assignment_schedule_instance.getRoleAssignmentScheduleInstance", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armauthorization.NewRoleAssignmentScheduleInstancesClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_assignment_schedule_instance.getRoleAssignmentScheduleInstance", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, strings.TrimPrefix(scope, "/"), getLastPathElement(id), nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_assignment_schedule_instance.getRoleAssignmentScheduleInstance", "api_error", err)
+		return nil, err
+	}
+
+	item := RoleAssignmentScheduleInstanceInfo{RoleAssignmentScheduleInstance: op.RoleAssignmentScheduleInstance}
+	if op.Properties != nil {
+		item.Scope = op.Properties.Scope
+	}
+	return item, nil
+}
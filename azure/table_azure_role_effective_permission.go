@@ -0,0 +1,409 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// RoleEffectivePermission is an action a principal is allowed to perform at a scope through a role assignment
+type RoleEffectivePermission struct {
+	PrincipalID           *string
+	PrincipalType         *armauthorization.PrincipalType
+	AssignmentPrincipalID *string
+	Scope                 string
+	AssignmentScope       *string
+	Inherited             bool
+	RoleAssignmentID      *string
+	RoleDefinitionID      *string
+	RoleName              *string
+	RoleType              *string
+	Action                string
+	GrantedBy             string
+	IsDataAction          bool
+	NotActions            []*string
+	Condition             *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureRoleEffectivePermission(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_role_effective_permission",
+		Description: "Azure Role Effective Permission",
+		List: &plugin.ListConfig{
+			Hydrate: listRoleEffectivePermissions,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "roleAssignments/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "principal_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "action",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "is_data_action",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "principal_id",
+				Description: "The ID of the principal the permission is granted to. If a principal ID is given, this includes permissions granted to the groups the principal is a member of.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PrincipalID"),
+			},
+			{
+				Name:        "scope",
+				Description: "The scope the permission is effective at. Defaults to the subscription for role assignments at or above the subscription.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "action",
+				Description: "The effective action, or the action pattern of the role definition if no action is given. Patterns may contain '*' wildcards.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "granted_by",
+				Description: "The action pattern of the role definition that grants the action.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_data_action",
+				Description: "True if the action is a data action, granted by the dataActions of the role definition. Set it to true to match action against the dataActions of the role definitions, otherwise action is matched against their actions.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "not_actions",
+				Description: "The notActions (or notDataActions for data actions) of the role definition permission that grants the action.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "inherited",
+				Description: "True if the permission is inherited from a role assignment at a parent scope.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "assignment_scope",
+				Description: "The scope of the role assignment that grants the permission.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "assignment_principal_id",
+				Description: "The principal of the role assignment that grants the permission. Differs from principal_id when the permission is granted to a group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AssignmentPrincipalID"),
+			},
+			{
+				Name:        "principal_type",
+				Description: "The type of the principal of the role assignment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "role_assignment_id",
+				Description: "The ID of the role assignment that grants the permission.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RoleAssignmentID"),
+			},
+			{
+				Name:        "role_definition_id",
+				Description: "The ID of the role definition that grants the permission.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RoleDefinitionID"),
+			},
+			{
+				Name:        "role_name",
+				Description: "The name of the role definition that grants the permission.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "role_type",
+				Description: "The type of the role definition, i.e. BuiltInRole or CustomRole.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "condition",
+				Description: "The condition of the role assignment that limits the resources the permission applies to.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Action"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listRoleEffectivePermissions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_effective_permission.listRoleEffectivePermissions", "session_error", err)
+		return nil, err
+	}
+
+	assignmentsClient, err := armauthorization.NewRoleAssignmentsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_effective_permission.listRoleEffectivePermissions", "client_error", err)
+		return nil, err
+	}
+	definitionsClient, err := armauthorization.NewRoleDefinitionsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_effective_permission.listRoleEffectivePermissions", "client_error", err)
+		return nil, err
+	}
+
+	subscriptionScope := "/subscriptions/" + session.SubscriptionID
+	scope := d.EqualsQualString("scope")
+	principalID := d.EqualsQualString("principal_id")
+	action := d.EqualsQualString("action")
+
+	// A concrete action is either a control plane action or a data action, so it is only matched against the
+	// permissions of one kind. Without is_data_action, the action is a control plane action.
+	var isDataAction *bool
+	if d.EqualsQuals["is_data_action"] != nil {
+		value := d.EqualsQuals["is_data_action"].GetBoolValue()
+		isDataAction = &value
+	} else if action != "" {
+		value := false
+		isDataAction = &value
+	}
+
+	// atScope() returns the role assignments at or above the scope, and assignedTo() the role assignments of the
+	// principal, including those of the groups the principal is a member of
+	var filters []string
+	if scope != "" {
+		filters = append(filters, "atScope()")
+	}
+	if principalID != "" {
+		filters = append(filters, fmt.Sprintf("assignedTo('%s')", strings.ReplaceAll(principalID, "'", "''")))
+	}
+	filter := strings.Join(filters, " and ")
+
+	listScope := scope
+	if listScope == "" {
+		listScope = subscriptionScope
+	}
+	options := &armauthorization.RoleAssignmentsClientListForScopeOptions{}
+	if filter != "" {
+		options.Filter = &filter
+	}
+	pager := assignmentsClient.NewListForScopePager(strings.TrimPrefix(listScope, "/"), options)
+
+	roleDefinitions, err := listRoleDefinitionsByName(ctx, definitionsClient, subscriptionScope)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_effective_permission.listRoleEffectivePermissions", "api_error", err)
+		return nil, err
+	}
+
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_role_effective_permission.listRoleEffectivePermissions", "api_error", err)
+			return nil, err
+		}
+
+		for _, assignment := range page.Value {
+			if assignment.Properties == nil || assignment.Properties.RoleDefinitionID == nil {
+				continue
+			}
+
+			roleDefinition, err := getRoleDefinitionForAssignment(ctx, definitionsClient, roleDefinitions, *assignment.Properties.RoleDefinitionID)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_role_effective_permission.listRoleEffectivePermissions", "api_error", err)
+				return nil, err
+			}
+			if roleDefinition == nil || roleDefinition.Properties == nil {
+				continue
+			}
+
+			base := RoleEffectivePermission{
+				PrincipalID:           assignment.Properties.PrincipalID,
+				PrincipalType:         assignment.Properties.PrincipalType,
+				AssignmentPrincipalID: assignment.Properties.PrincipalID,
+				AssignmentScope:       assignment.Properties.Scope,
+				RoleAssignmentID:      assignment.ID,
+				RoleDefinitionID:      assignment.Properties.RoleDefinitionID,
+				RoleName:              roleDefinition.Properties.RoleName,
+				RoleType:              roleDefinition.Properties.RoleType,
+				Condition:             assignment.Properties.Condition,
+			}
+			if principalID != "" {
+				base.PrincipalID = &principalID
+			}
+			base.Scope, base.Inherited = getRoleEffectivePermissionScope(scope, subscriptionScope, assignment.Properties.Scope)
+
+			for _, permission := range expandRoleEffectivePermissions(base, roleDefinition.Properties.Permissions, action, isDataAction) {
+				d.StreamListItem(ctx, permission)
+				// Check if context has been cancelled or if the limit has been hit (if specified)
+				// if there is a limit, it will return the number of rows required to reach this limit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// listRoleDefinitionsByName lists the role definitions assignable at the scope, keyed by lower case name (a GUID),
+// since role assignments reference role definitions with IDs of varying scope
+func listRoleDefinitionsByName(ctx context.Context, client *armauthorization.RoleDefinitionsClient, scope string) (map[string]*armauthorization.RoleDefinition, error) {
+	roleDefinitions := make(map[string]*armauthorization.RoleDefinition)
+	pager := client.NewListPager(strings.TrimPrefix(scope, "/"), nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, roleDefinition := range page.Value {
+			if roleDefinition.Name != nil {
+				roleDefinitions[strings.ToLower(*roleDefinition.Name)] = roleDefinition
+			}
+		}
+	}
+	return roleDefinitions, nil
+}
+
+// getRoleDefinitionForAssignment looks up the role definition of a role assignment, getting role definitions
+// that are not assignable at the subscription, e.g. custom roles of management groups, by ID
+func getRoleDefinitionForAssignment(ctx context.Context, client *armauthorization.RoleDefinitionsClient, roleDefinitions map[string]*armauthorization.RoleDefinition, roleDefinitionID string) (*armauthorization.RoleDefinition, error) {
+	name := strings.ToLower(getLastPathElement(roleDefinitionID))
+	if roleDefinition, ok := roleDefinitions[name]; ok {
+		return roleDefinition, nil
+	}
+
+	op, err := client.GetByID(ctx, roleDefinitionID, nil)
+	if err != nil {
+		// Role assignments may reference deleted role definitions
+		if strings.Contains(err.Error(), "RoleDefinitionDoesNotExist") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	roleDefinitions[name] = &op.RoleDefinition
+
+	return &op.RoleDefinition, nil
+}
+
+// getRoleEffectivePermissionScope returns the scope the permissions of a role assignment are effective at, and whether
+// they are inherited. Role assignments above the requested scope, or above the subscription if no scope is requested,
+// are effective at that scope. Role assignments below the subscription are effective at their own scope.
+func getRoleEffectivePermissionScope(scope string, subscriptionScope string, assignmentScope *string) (string, bool) {
+	if assignmentScope == nil {
+		return scope, false
+	}
+	if scope != "" {
+		return scope, !strings.EqualFold(strings.Trim(scope, "/"), strings.Trim(*assignmentScope, "/"))
+	}
+	if strings.HasPrefix(strings.ToLower(*assignmentScope), strings.ToLower(subscriptionScope)) {
+		return *assignmentScope, false
+	}
+	return subscriptionScope, true
+}
+
+// expandRoleEffectivePermissions returns one permission per action of the role definition. If isDataAction is set,
+// only the actions (or dataActions) of that kind are returned. If an action is given, only the permissions that grant
+// the action, and whose notActions (or notDataActions) do not exclude it, are returned.
+func expandRoleEffectivePermissions(base RoleEffectivePermission, permissions []*armauthorization.Permission, action string, isDataAction *bool) []RoleEffectivePermission {
+	var result []RoleEffectivePermission
+	for _, permission := range permissions {
+		if permission == nil {
+			continue
+		}
+		if isDataAction == nil || !*isDataAction {
+			result = append(result, expandRoleActions(base, permission.Actions, permission.NotActions, false, action)...)
+		}
+		if isDataAction == nil || *isDataAction {
+			result = append(result, expandRoleActions(base, permission.DataActions, permission.NotDataActions, true, action)...)
+		}
+	}
+	return result
+}
+
+func expandRoleActions(base RoleEffectivePermission, actions []*string, notActions []*string, isDataAction bool, action string) []RoleEffectivePermission {
+	var result []RoleEffectivePermission
+	for _, pattern := range actions {
+		if pattern == nil {
+			continue
+		}
+
+		row := base
+		row.GrantedBy = *pattern
+		row.Action = *pattern
+		row.IsDataAction = isDataAction
+		row.NotActions = notActions
+
+		if action != "" {
+			if !matchRoleAction(*pattern, action) || isRoleActionExcluded(notActions, action) {
+				continue
+			}
+			row.Action = action
+		}
+
+		result = append(result, row)
+	}
+	return result
+}
+
+func isRoleActionExcluded(notActions []*string, action string) bool {
+	for _, notAction := range notActions {
+		if notAction != nil && matchRoleAction(*notAction, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRoleAction reports whether an action matches an action pattern of a role definition.
+// Matching is case insensitive, and '*' matches any sequence of characters, including '/'.
+func matchRoleAction(pattern string, action string) bool {
+	pattern = strings.ToLower(pattern)
+	action = strings.ToLower(action)
+
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == action
+	}
+
+	if !strings.HasPrefix(action, parts[0]) {
+		return false
+	}
+	action = action[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(action, part)
+		if index < 0 {
+			return false
+		}
+		action = action[index+len(part):]
+	}
+
+	return strings.HasSuffix(action, parts[len(parts)-1])
+}
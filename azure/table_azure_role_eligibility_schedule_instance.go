@@ -0,0 +1,293 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// RoleEligibilityScheduleInstanceInfo is a role eligibility schedule instance, with the scope reported with the casing used in the quals
+type RoleEligibilityScheduleInstanceInfo struct {
+	armauthorization.RoleEligibilityScheduleInstance
+	Scope *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureRoleEligibilityScheduleInstance(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_role_eligibility_schedule_instance",
+		Description: "Azure Role Eligibility Schedule Instance",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getRoleEligibilityScheduleInstance,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "roleEligibilityScheduleInstances/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"RoleEligibilityScheduleInstanceNotFound", "ResourceNotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listRoleEligibilityScheduleInstances,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "roleEligibilityScheduleInstances/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				// The scope may be given with or without the leading "/" (forward slash), in any casing
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "principal_id",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the role eligibility schedule instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the role eligibility schedule instance.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "principal_id",
+				Description: "The ID of the principal eligible for the role.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.PrincipalID"),
+			},
+			{
+				Name:        "principal_type",
+				Description: "The type of the principal, e.g. User, Group or ServicePrincipal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.PrincipalType"),
+			},
+			{
+				Name:        "principal_display_name",
+				Description: "The display name of the principal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ExpandedProperties.Principal.DisplayName"),
+			},
+			{
+				Name:        "role_definition_id",
+				Description: "The ID of the role definition the principal is eligible for.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.RoleDefinitionID"),
+			},
+			{
+				Name:        "role_name",
+				Description: "The name of the role definition the principal is eligible for.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ExpandedProperties.RoleDefinition.DisplayName"),
+			},
+			{
+				Name:        "scope",
+				Description: "The scope of the role eligibility.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Scope"),
+			},
+			{
+				Name:        "scope_display_name",
+				Description: "The display name of the scope of the role eligibility.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ExpandedProperties.Scope.DisplayName"),
+			},
+			{
+				Name:        "member_type",
+				Description: "The membership type of the role eligibility. Possible values are Direct, Group and Inherited.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.MemberType"),
+			},
+			{
+				Name:        "status",
+				Description: "The status of the role eligibility schedule instance, e.g. Provisioned.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Status"),
+			},
+			{
+				Name:        "start_date_time",
+				Description: "The time the role eligibility starts.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.StartDateTime"),
+			},
+			{
+				Name:        "end_date_time",
+				Description: "The time the role eligibility ends. Empty for permanent eligibilities.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.EndDateTime"),
+			},
+			{
+				Name:        "created_on",
+				Description: "The time the role eligibility schedule was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.CreatedOn"),
+			},
+			{
+				Name:        "condition",
+				Description: "The condition on the role eligibility, limiting the resources it applies to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Condition"),
+			},
+			{
+				Name:        "role_eligibility_schedule_id",
+				Description: "The ID of the role eligibility schedule the instance is created from.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.RoleEligibilityScheduleID"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Authorization/roleEligibilityScheduleInstances).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listRoleEligibilityScheduleInstances(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_eligibility_schedule_instance.listRoleEligibilityScheduleInstances", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armauthorization.NewRoleEligibilityScheduleInstancesClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_eligibility_schedule_instance.listRoleEligibilityScheduleInstances", "client_error", err)
+		return nil, err
+	}
+
+	scope := d.EqualsQualString("scope")
+	listScope, filter := getRoleScheduleInstanceListScope(d, session.SubscriptionID)
+
+	pager := client.NewListForScopePager(listScope, &armauthorization.RoleEligibilityScheduleInstancesClientListForScopeOptions{Filter: filter})
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_role_eligibility_schedule_instance.listRoleEligibilityScheduleInstances", "api_error", err)
+			return nil, err
+		}
+
+		for _, instance := range page.Value {
+			item := RoleEligibilityScheduleInstanceInfo{RoleEligibilityScheduleInstance: *instance}
+			if instance.Properties != nil {
+				item.Scope = instance.Properties.Scope
+			}
+
+			if scope != "" {
+				if item.Scope == nil || !isSameRoleAssignmentScope(*item.Scope, scope) {
+					continue
+				}
+				// Report the scope as queried, so that the instance is not dropped for differing in casing or in the leading slash
+				item.Scope = &scope
+			}
+
+			d.StreamListItem(ctx, item)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getRoleEligibilityScheduleInstance(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+	scope := getAuthorizationResourceScope(id, "roleEligibilityScheduleInstances")
+	if scope == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_eligibility_schedule_instance.getRoleEligibilityScheduleInstance", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armauthorization.NewRoleEligibilityScheduleInstancesClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_eligibility_schedule_instance.getRoleEligibilityScheduleInstance", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, strings.TrimPrefix(scope, "/"), getLastPathElement(id), nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_eligibility_schedule_instance.getRoleEligibilityScheduleInstance", "api_error", err)
+		return nil, err
+	}
+
+	item := RoleEligibilityScheduleInstanceInfo{RoleEligibilityScheduleInstance: op.RoleEligibilityScheduleInstance}
+	if op.Properties != nil {
+		item.Scope = op.Properties.Scope
+	}
+	return item, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// getRoleScheduleInstanceListScope returns the scope and filter to list the role schedule instances of the quals.
+// atScope() returns the instances at or above the scope, and principalId eq the instances of the principal at,
+// above or below the scope. The API accepts a single filter, so instances at other scopes are dropped by the caller.
+func getRoleScheduleInstanceListScope(d *plugin.QueryData, subscriptionID string) (string, *string) {
+	listScope := "subscriptions/" + subscriptionID
+	if scope := d.EqualsQualString("scope"); scope != "" {
+		listScope = strings.TrimPrefix(normalizeRoleAssignmentScope(scope), "/")
+	}
+
+	var filter string
+	switch {
+	case d.EqualsQualString("principal_id") != "":
+		filter = fmt.Sprintf("principalId eq '%s'", strings.ReplaceAll(d.EqualsQualString("principal_id"), "'", "''"))
+	case d.EqualsQualString("scope") != "":
+		filter = "atScope()"
+	default:
+		return listScope, nil
+	}
+	return listScope, &filter
+}
+
+// getAuthorizationResourceScope returns the scope part of the ID of a Microsoft.Authorization resource
+func getAuthorizationResourceScope(id string, resourceType string) string {
+	index := strings.Index(strings.ToLower(id), strings.ToLower("/providers/Microsoft.Authorization/"+resourceType+"/"))
+	if index < 0 {
+		return ""
+	}
+	return id[:index]
+}
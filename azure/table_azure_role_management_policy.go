@@ -0,0 +1,362 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// RoleManagementPolicyInfo is a role management policy, with the role it applies to and the settings of its rules
+type RoleManagementPolicyInfo struct {
+	armauthorization.RoleManagementPolicy
+	Scope                                 *string
+	RoleDefinitionID                      *string
+	MaxActivationDuration                 *string
+	ActivationRequiresMfa                 bool
+	ActivationRequiresJustification       bool
+	ActivationRequiresTicket              bool
+	ActivationRequiresApproval            *bool
+	ActivationApprovers                   []*armauthorization.UserSet
+	ActivationAuthenticationContext       *string
+	EligibilityExpirationRequired         *bool
+	MaxEligibilityDuration                *string
+	ActiveAssignmentExpirationRequired    *bool
+	MaxActiveAssignmentDuration           *string
+	ActiveAssignmentRequiresMfa           bool
+	ActiveAssignmentRequiresJustification bool
+}
+
+//// TABLE DEFINITION
+
+func tableAzureRoleManagementPolicy(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_role_management_policy",
+		Description: "Azure Role Management Policy",
+		List: &plugin.ListConfig{
+			Hydrate: listRoleManagementPolicies,
+			Tags: map[string]string{
+				"service": "Microsoft.Authorization",
+				"action":  "roleManagementPolicies/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				// The scope may be given with or without the leading "/" (forward slash), in any casing
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the role management policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the role management policy.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the role management policy.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the role management policy.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "scope",
+				Description: "The scope of the role management policy.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Scope"),
+			},
+			{
+				Name:        "role_definition_id",
+				Description: "The ID of the role definition the policy applies to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RoleDefinitionID"),
+			},
+			{
+				Name:        "is_organization_default",
+				Description: "True if the policy is the default policy of the organization.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.IsOrganizationDefault"),
+			},
+			{
+				Name:        "max_activation_duration",
+				Description: "The maximum duration of a role activation, as an ISO 8601 duration, e.g. PT8H.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "activation_requires_mfa",
+				Description: "True if multi-factor authentication is required to activate the role.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ActivationRequiresMfa"),
+			},
+			{
+				Name:        "activation_requires_justification",
+				Description: "True if a justification is required to activate the role.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "activation_requires_ticket",
+				Description: "True if ticket information is required to activate the role.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "activation_requires_approval",
+				Description: "True if an approval is required to activate the role.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "activation_approvers",
+				Description: "The primary approvers of role activations.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "activation_authentication_context",
+				Description: "The Conditional Access authentication context required to activate the role, if enabled.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "eligibility_expiration_required",
+				Description: "True if eligible assignments of the role must expire.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "max_eligibility_duration",
+				Description: "The maximum duration of an eligible assignment of the role, as an ISO 8601 duration, e.g. P365D.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "active_assignment_expiration_required",
+				Description: "True if active assignments of the role must expire.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "max_active_assignment_duration",
+				Description: "The maximum duration of an active assignment of the role, as an ISO 8601 duration, e.g. P180D.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "active_assignment_requires_mfa",
+				Description: "True if multi-factor authentication is required to make an active assignment of the role.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("ActiveAssignmentRequiresMfa"),
+			},
+			{
+				Name:        "active_assignment_requires_justification",
+				Description: "True if a justification is required to make an active assignment of the role.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "last_modified_date_time",
+				Description: "The time the role management policy was last modified.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.LastModifiedDateTime"),
+			},
+			{
+				Name:        "last_modified_by",
+				Description: "The principal that last modified the role management policy.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.LastModifiedBy"),
+			},
+			{
+				Name:        "rules",
+				Description: "The rules of the role management policy.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Rules"),
+			},
+			{
+				Name:        "effective_rules",
+				Description: "The effective rules of the role management policy, including the rules inherited from parent scopes.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.EffectiveRules"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Authorization/roleManagementPolicies).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listRoleManagementPolicies(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_management_policy.listRoleManagementPolicies", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armauthorization.NewRoleManagementPoliciesClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_management_policy.listRoleManagementPolicies", "client_error", err)
+		return nil, err
+	}
+	assignmentsClient, err := armauthorization.NewRoleManagementPolicyAssignmentsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_role_management_policy.listRoleManagementPolicies", "client_error", err)
+		return nil, err
+	}
+
+	scope := d.EqualsQualString("scope")
+	listScope := "subscriptions/" + session.SubscriptionID
+	if scope != "" {
+		listScope = strings.TrimPrefix(normalizeRoleAssignmentScope(scope), "/")
+	}
+
+	// The policies do not reference the role they apply to, the policy assignments do
+	roleDefinitionIDs := make(map[string]*string)
+	assignmentsPager := assignmentsClient.NewListForScopePager(listScope, nil)
+	for assignmentsPager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := assignmentsPager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_role_management_policy.listRoleManagementPolicies", "api_error", err)
+			return nil, err
+		}
+		for _, assignment := range page.Value {
+			if assignment.Properties != nil && assignment.Properties.PolicyID != nil {
+				roleDefinitionIDs[strings.ToLower(*assignment.Properties.PolicyID)] = assignment.Properties.RoleDefinitionID
+			}
+		}
+	}
+
+	pager := client.NewListForScopePager(listScope, nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_role_management_policy.listRoleManagementPolicies", "api_error", err)
+			return nil, err
+		}
+
+		for _, policy := range page.Value {
+			item := roleManagementPolicyInfo(policy)
+			if policy.ID != nil {
+				item.RoleDefinitionID = roleDefinitionIDs[strings.ToLower(*policy.ID)]
+			}
+			if scope != "" {
+				// Report the scope as queried, so that the policy is not dropped for differing in casing or in the leading slash
+				item.Scope = &scope
+			}
+
+			d.StreamListItem(ctx, item)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// roleManagementPolicyInfo wraps a role management policy, extracting the settings of its rules.
+// Rules apply to the activation of eligible assignments when targeting the end user at assignment level,
+// to eligible assignments when targeting the admin at eligibility level, and to active assignments when
+// targeting the admin at assignment level.
+func roleManagementPolicyInfo(policy *armauthorization.RoleManagementPolicy) RoleManagementPolicyInfo {
+	item := RoleManagementPolicyInfo{RoleManagementPolicy: *policy}
+	if policy.Properties == nil {
+		return item
+	}
+	item.Scope = policy.Properties.Scope
+
+	rules := policy.Properties.EffectiveRules
+	if len(rules) == 0 {
+		rules = policy.Properties.Rules
+	}
+
+	for _, rule := range rules {
+		switch r := rule.(type) {
+		case *armauthorization.RoleManagementPolicyExpirationRule:
+			switch getRoleManagementPolicyRuleTarget(r.Target) {
+			case "enduser/assignment":
+				item.MaxActivationDuration = r.MaximumDuration
+			case "admin/eligibility":
+				item.EligibilityExpirationRequired = r.IsExpirationRequired
+				item.MaxEligibilityDuration = r.MaximumDuration
+			case "admin/assignment":
+				item.ActiveAssignmentExpirationRequired = r.IsExpirationRequired
+				item.MaxActiveAssignmentDuration = r.MaximumDuration
+			}
+		case *armauthorization.RoleManagementPolicyEnablementRule:
+			for _, enabledRule := range r.EnabledRules {
+				if enabledRule == nil {
+					continue
+				}
+				switch getRoleManagementPolicyRuleTarget(r.Target) + "/" + string(*enabledRule) {
+				case "enduser/assignment/" + string(armauthorization.EnablementRulesMultiFactorAuthentication):
+					item.ActivationRequiresMfa = true
+				case "enduser/assignment/" + string(armauthorization.EnablementRulesJustification):
+					item.ActivationRequiresJustification = true
+				case "enduser/assignment/" + string(armauthorization.EnablementRulesTicketing):
+					item.ActivationRequiresTicket = true
+				case "admin/assignment/" + string(armauthorization.EnablementRulesMultiFactorAuthentication):
+					item.ActiveAssignmentRequiresMfa = true
+				case "admin/assignment/" + string(armauthorization.EnablementRulesJustification):
+					item.ActiveAssignmentRequiresJustification = true
+				}
+			}
+		case *armauthorization.RoleManagementPolicyApprovalRule:
+			if getRoleManagementPolicyRuleTarget(r.Target) != "enduser/assignment" || r.Setting == nil {
+				continue
+			}
+			item.ActivationRequiresApproval = r.Setting.IsApprovalRequired
+			for _, stage := range r.Setting.ApprovalStages {
+				if stage != nil {
+					item.ActivationApprovers = append(item.ActivationApprovers, stage.PrimaryApprovers...)
+				}
+			}
+		case *armauthorization.RoleManagementPolicyAuthenticationContextRule:
+			if getRoleManagementPolicyRuleTarget(r.Target) == "enduser/assignment" && r.IsEnabled != nil && *r.IsEnabled {
+				item.ActivationAuthenticationContext = r.ClaimValue
+			}
+		}
+	}
+
+	return item
+}
+
+// getRoleManagementPolicyRuleTarget returns the lower case caller and level targeted by a rule, e.g. enduser/assignment
+func getRoleManagementPolicyRuleTarget(target *armauthorization.RoleManagementPolicyRuleTarget) string {
+	if target == nil || target.Caller == nil || target.Level == nil {
+		return ""
+	}
+	return strings.ToLower(*target.Caller + "/" + *target.Level)
+}
@@ -0,0 +1,310 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// The SDK has no client for Microsoft.BillingBenefits in this version, so savings plans are listed with a raw ARM call
+const billingBenefitsAPIVersion = "2022-11-01"
+
+// SavingsPlan is a savings plan returned by the Microsoft.BillingBenefits API
+type SavingsPlan struct {
+	ID   *string `json:"id"`
+	Name *string `json:"name"`
+	Type *string `json:"type"`
+	Sku  *struct {
+		Name *string `json:"name"`
+	} `json:"sku"`
+	Properties *SavingsPlanProperties `json:"properties"`
+}
+
+// SavingsPlanProperties are the properties of a savings plan
+type SavingsPlanProperties struct {
+	DisplayName                  *string                `json:"displayName"`
+	ProvisioningState            *string                `json:"provisioningState"`
+	DisplayProvisioningState     *string                `json:"displayProvisioningState"`
+	UserFriendlyAppliedScopeType *string                `json:"userFriendlyAppliedScopeType"`
+	BillingScopeID               *string                `json:"billingScopeId"`
+	BillingProfileID             *string                `json:"billingProfileId"`
+	CustomerID                   *string                `json:"customerId"`
+	BillingAccountID             *string                `json:"billingAccountId"`
+	Term                         *string                `json:"term"`
+	BillingPlan                  *string                `json:"billingPlan"`
+	AppliedScopeType             *string                `json:"appliedScopeType"`
+	AppliedScopeProperties       map[string]interface{} `json:"appliedScopeProperties"`
+	Commitment                   *struct {
+		Grain        *string  `json:"grain"`
+		CurrencyCode *string  `json:"currencyCode"`
+		Amount       *float64 `json:"amount"`
+	} `json:"commitment"`
+	PurchaseDateTime   *time.Time             `json:"purchaseDateTime"`
+	BenefitStartTime   *time.Time             `json:"benefitStartTime"`
+	EffectiveDateTime  *time.Time             `json:"effectiveDateTime"`
+	ExpiryDateTime     *time.Time             `json:"expiryDateTime"`
+	Renew              *bool                  `json:"renew"`
+	Utilization        map[string]interface{} `json:"utilization"`
+	ExtendedStatusInfo map[string]interface{} `json:"extendedStatusInfo"`
+}
+
+type savingsPlanListResult struct {
+	Value    []SavingsPlan `json:"value"`
+	NextLink *string       `json:"nextLink"`
+}
+
+//// TABLE DEFINITION
+
+func tableAzureSavingsPlan(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_savings_plan",
+		Description: "Azure Savings Plan",
+		List: &plugin.ListConfig{
+			Hydrate: listSavingsPlans,
+			Tags: map[string]string{
+				"service": "Microsoft.BillingBenefits",
+				"action":  "savingsPlans/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the savings plan.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the savings plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the savings plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName"),
+			},
+			{
+				Name:        "sku_name",
+				Description: "The SKU of the savings plan, e.g. Compute_Savings_Plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Sku.Name"),
+			},
+			{
+				Name:        "provisioning_state",
+				Description: "The provisioning state of the savings plan, e.g. Succeeded, Cancelled or Expired.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ProvisioningState"),
+			},
+			{
+				Name:        "display_provisioning_state",
+				Description: "The provisioning state of the savings plan as displayed in the portal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayProvisioningState"),
+			},
+			{
+				Name:        "term",
+				Description: "The term of the savings plan. Possible values are P1Y, P3Y and P5Y.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Term"),
+			},
+			{
+				Name:        "billing_plan",
+				Description: "How often the savings plan is billed, e.g. P1M for monthly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BillingPlan"),
+			},
+			{
+				Name:        "commitment_amount",
+				Description: "The hourly commitment of the savings plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.Commitment.Amount"),
+			},
+			{
+				Name:        "commitment_currency_code",
+				Description: "The currency of the commitment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Commitment.CurrencyCode"),
+			},
+			{
+				Name:        "commitment_grain",
+				Description: "The grain of the commitment, e.g. Hourly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Commitment.Grain"),
+			},
+			{
+				Name:        "applied_scope_type",
+				Description: "The type of scope the benefit is applied to. Possible values are Single, Shared and ManagementGroup.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AppliedScopeType"),
+			},
+			{
+				Name:        "user_friendly_applied_scope_type",
+				Description: "The type of scope the benefit is applied to, as displayed in the portal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.UserFriendlyAppliedScopeType"),
+			},
+			{
+				Name:        "applied_scope_properties",
+				Description: "The properties of the scope the benefit is applied to, e.g. the subscription, resource group or management group.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.AppliedScopeProperties"),
+			},
+			{
+				Name:        "billing_scope_id",
+				Description: "The subscription or billing account that is charged for the savings plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BillingScopeID"),
+			},
+			{
+				Name:        "billing_account_id",
+				Description: "The fully-qualified ID of the billing account of the savings plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BillingAccountID"),
+			},
+			{
+				Name:        "billing_profile_id",
+				Description: "The fully-qualified ID of the billing profile of the savings plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BillingProfileID"),
+			},
+			{
+				Name:        "customer_id",
+				Description: "The fully-qualified ID of the customer of the savings plan, for Microsoft Partner Agreement accounts.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.CustomerID"),
+			},
+			{
+				Name:        "purchase_date_time",
+				Description: "The time the savings plan was purchased.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.PurchaseDateTime"),
+			},
+			{
+				Name:        "benefit_start_time",
+				Description: "The time the benefit of the savings plan started.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.BenefitStartTime"),
+			},
+			{
+				Name:        "effective_date_time",
+				Description: "The time the savings plan was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.EffectiveDateTime"),
+			},
+			{
+				Name:        "expiry_date_time",
+				Description: "The time the savings plan expires.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.ExpiryDateTime"),
+			},
+			{
+				Name:        "renew",
+				Description: "Indicates whether the savings plan is renewed automatically when it expires.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.Renew"),
+			},
+			{
+				Name:        "utilization",
+				Description: "The utilization trend and the daily, weekly and monthly utilization aggregates of the savings plan.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Utilization"),
+			},
+			{
+				Name:        "extended_status_info",
+				Description: "The status code and message of the savings plan.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ExtendedStatusInfo"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.BillingBenefits/savingsPlanOrders/savingsPlans).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSavingsPlans(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_savings_plan.listSavingsPlans", "session_error", err)
+		return nil, err
+	}
+
+	client, err := arm.NewClient("armbillingbenefits", "v1", session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_savings_plan.listSavingsPlans", "client_error", err)
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("api-version", billingBenefitsAPIVersion)
+	nextLink := runtime.JoinPaths(client.Endpoint(), "/providers/Microsoft.BillingBenefits/savingsPlans") + "?" + params.Encode()
+
+	for nextLink != "" {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		req, err := runtime.NewRequest(ctx, http.MethodGet, nextLink)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_savings_plan.listSavingsPlans", "api_error", err)
+			return nil, err
+		}
+		resp, err := client.Pipeline().Do(req)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_savings_plan.listSavingsPlans", "api_error", err)
+			return nil, err
+		}
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			err = runtime.NewResponseError(resp)
+			plugin.Logger(ctx).Error("azure_savings_plan.listSavingsPlans", "api_error", err)
+			return nil, err
+		}
+
+		page := savingsPlanListResult{}
+		if err := runtime.UnmarshalAsJSON(resp, &page); err != nil {
+			plugin.Logger(ctx).Error("azure_savings_plan.listSavingsPlans", "api_error", err)
+			return nil, err
+		}
+
+		for _, savingsPlan := range page.Value {
+			d.StreamListItem(ctx, savingsPlan)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		nextLink = ""
+		if page.NextLink != nil {
+			nextLink = *page.NextLink
+		}
+	}
+
+	return nil, nil
+}
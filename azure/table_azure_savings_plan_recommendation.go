@@ -0,0 +1,328 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// SavingsPlanRecommendationInfo is a savings plan purchase recommendation with the scope it was listed for
+type SavingsPlanRecommendationInfo struct {
+	ID                           *string
+	Name                         *string
+	Kind                         *armcostmanagement.BenefitKind
+	Scope                        string
+	RecommendationSubscriptionID *string
+	RecommendationResourceGroup  *string
+	Properties                   *armcostmanagement.BenefitRecommendationProperties
+}
+
+//// TABLE DEFINITION
+
+func tableAzureSavingsPlanRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_savings_plan_recommendation",
+		Description: "Azure Savings Plan Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listSavingsPlanRecommendations,
+			Tags: map[string]string{
+				"service": "Microsoft.CostManagement",
+				"action":  "benefitRecommendations/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "billing_account_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "billing_profile_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "recommendation_scope",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "look_back_period",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "term",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the recommendation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the recommendation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "kind",
+				Description: "The kind of benefit the recommendation is for, e.g. SavingsPlan.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope",
+				Description: "The scope the recommendations are listed for. Defaults to the current subscription if not specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "billing_account_id",
+				Description: "The billing account to list recommendations for.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("billing_account_id"),
+			},
+			{
+				Name:        "billing_profile_id",
+				Description: "The billing profile to list recommendations for. Requires billing_account_id.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("billing_profile_id"),
+			},
+			{
+				Name:        "recommendation_scope",
+				Description: "The scope of the recommended savings plan, either Single or Shared.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Scope"),
+			},
+			{
+				Name:        "look_back_period",
+				Description: "The number of days of usage the recommendation is based on, e.g. Last7Days, Last30Days or Last60Days.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.LookBackPeriod"),
+			},
+			{
+				Name:        "term",
+				Description: "The term of the recommended savings plan, e.g. P1Y or P3Y.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Term"),
+			},
+			{
+				Name:        "arm_sku_name",
+				Description: "The ARM SKU name of the recommended savings plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ArmSKUName"),
+			},
+			{
+				Name:        "commitment_granularity",
+				Description: "The grain of the commitment amount, e.g. Hourly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.CommitmentGranularity"),
+			},
+			{
+				Name:        "commitment_amount",
+				Description: "The recommended commitment amount per commitment granularity.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.CommitmentAmount"),
+			},
+			{
+				Name:        "savings_amount",
+				Description: "The savings over the look back period if the recommended savings plan had been purchased.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.SavingsAmount"),
+			},
+			{
+				Name:        "savings_percentage",
+				Description: "The savings as a percentage of the cost without the benefit.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.SavingsPercentage"),
+			},
+			{
+				Name:        "coverage_percentage",
+				Description: "The percentage of the eligible cost covered by the recommended savings plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.CoveragePercentage"),
+			},
+			{
+				Name:        "average_utilization_percentage",
+				Description: "The estimated average utilization of the recommended savings plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.AverageUtilizationPercentage"),
+			},
+			{
+				Name:        "benefit_cost",
+				Description: "The cost of the recommended savings plan over the look back period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.BenefitCost"),
+			},
+			{
+				Name:        "overage_cost",
+				Description: "The cost of the usage not covered by the recommended savings plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.OverageCost"),
+			},
+			{
+				Name:        "wastage_cost",
+				Description: "The cost of the unused commitment of the recommended savings plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.WastageCost"),
+			},
+			{
+				Name:        "total_cost",
+				Description: "The total cost with the recommended savings plan, including the overage.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.RecommendationDetails.TotalCost"),
+			},
+			{
+				Name:        "cost_without_benefit",
+				Description: "The cost of the usage over the look back period without any savings plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.CostWithoutBenefit"),
+			},
+			{
+				Name:        "currency_code",
+				Description: "The currency of the cost and savings amounts.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.CurrencyCode"),
+			},
+			{
+				Name:        "first_consumption_date",
+				Description: "The date of the first consumption in the look back period.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.FirstConsumptionDate"),
+			},
+			{
+				Name:        "last_consumption_date",
+				Description: "The date of the last consumption in the look back period.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.LastConsumptionDate"),
+			},
+			{
+				Name:        "total_hours",
+				Description: "The number of hours in the look back period.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.TotalHours"),
+			},
+			{
+				Name:        "recommendation_subscription_id",
+				Description: "The subscription the recommendation applies to, for single scope recommendations.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RecommendationSubscriptionID"),
+			},
+			{
+				Name:        "recommendation_resource_group",
+				Description: "The resource group the recommendation applies to, for single scope recommendations.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "all_recommendation_details",
+				Description: "The savings of every commitment amount evaluated for the recommendation.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.AllRecommendationDetails.Value"),
+			},
+			{
+				Name:        "usage",
+				Description: "The eligible usage charges over the look back period the recommendation is based on.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Usage"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSavingsPlanRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	scope, err := getCostManagementScope(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_savings_plan_recommendation.listSavingsPlanRecommendations", "scope_error", err)
+		return nil, err
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_savings_plan_recommendation.listSavingsPlanRecommendations", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armcostmanagement.NewBenefitRecommendationsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_savings_plan_recommendation.listSavingsPlanRecommendations", "client_error", err)
+		return nil, err
+	}
+
+	filterColumns := map[string]string{
+		"recommendation_scope": "properties/scope",
+		"look_back_period":     "properties/lookBackPeriod",
+		"term":                 "properties/term",
+	}
+	var filters []string
+	for _, column := range []string{"recommendation_scope", "look_back_period", "term"} {
+		if value := d.EqualsQualString(column); value != "" {
+			filters = append(filters, fmt.Sprintf("%s eq '%s'", filterColumns[column], strings.ReplaceAll(value, "'", "''")))
+		}
+	}
+
+	options := &armcostmanagement.BenefitRecommendationsClientListOptions{
+		Expand: to.Ptr("properties/usage,properties/allRecommendationDetails"),
+	}
+	if len(filters) > 0 {
+		options.Filter = to.Ptr(strings.Join(filters, " AND "))
+	}
+
+	// The billing scope is part of the URL path, which already starts with a slash
+	pager := client.NewListPager(strings.TrimPrefix(scope, "/"), options)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_savings_plan_recommendation.listSavingsPlanRecommendations", "api_error", err)
+			return nil, err
+		}
+
+		for _, recommendation := range page.Value {
+			if recommendation == nil {
+				continue
+			}
+			row := &SavingsPlanRecommendationInfo{
+				ID:    recommendation.ID,
+				Name:  recommendation.Name,
+				Kind:  recommendation.Kind,
+				Scope: scope,
+			}
+			if recommendation.Properties != nil {
+				row.Properties = recommendation.Properties.GetBenefitRecommendationProperties()
+				if single, ok := recommendation.Properties.(*armcostmanagement.SingleScopeBenefitRecommendationProperties); ok {
+					row.RecommendationSubscriptionID = single.SubscriptionID
+					row.RecommendationResourceGroup = single.ResourceGroup
+				}
+			}
+			d.StreamListItem(ctx, row)
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
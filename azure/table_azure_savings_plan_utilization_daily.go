@@ -0,0 +1,198 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement/v2"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+	"github.com/turbot/steampipe-plugin-sdk/v5/query_cache"
+)
+
+// SavingsPlanUtilizationInfo pairs a savings plan utilization summary with the billing scope it was listed from
+type SavingsPlanUtilizationInfo struct {
+	BillingAccountID string
+	BillingProfileID *string
+	*armcostmanagement.SavingsPlanUtilizationSummary
+}
+
+//// TABLE DEFINITION
+
+func tableAzureSavingsPlanUtilizationDaily(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_savings_plan_utilization_daily",
+		Description: "Azure Savings Plan Utilization Daily",
+		List: &plugin.ListConfig{
+			Hydrate: listSavingsPlanUtilizationDaily,
+			Tags: map[string]string{
+				"service": "Microsoft.CostManagement",
+				"action":  "benefitUtilizationSummaries/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "billing_account_id",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "billing_profile_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:       "usage_date",
+					Require:    plugin.Optional,
+					Operators:  []string{"=", ">", ">=", "<", "<="},
+					CacheMatch: query_cache.CacheMatchExact,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "billing_account_id",
+				Description: "The billing account the savings plans belong to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BillingAccountID"),
+			},
+			{
+				Name:        "billing_profile_id",
+				Description: "The billing profile the savings plans belong to, if specified in the where clause.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BillingProfileID"),
+			},
+			{
+				Name:        "savings_plan_order_id",
+				Description: "The fully qualified ID of the savings plan order.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BenefitOrderID"),
+			},
+			{
+				Name:        "savings_plan_id",
+				Description: "The fully qualified ID of the savings plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BenefitID"),
+			},
+			{
+				Name:        "usage_date",
+				Description: "The day the utilization is reported for. Defaults to the last 30 days if not specified in the where clause.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.UsageDate"),
+			},
+			{
+				Name:        "arm_sku_name",
+				Description: "The ARM SKU name of the savings plan, e.g. Compute_Savings_Plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ArmSKUName"),
+			},
+			{
+				Name:        "benefit_type",
+				Description: "The kind of benefit, e.g. SavingsPlan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.BenefitType"),
+			},
+			{
+				Name:        "avg_utilization_percentage",
+				Description: "The average hourly utilization percentage during the day.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.AvgUtilizationPercentage"),
+			},
+			{
+				Name:        "min_utilization_percentage",
+				Description: "The minimum hourly utilization percentage during the day.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.MinUtilizationPercentage"),
+			},
+			{
+				Name:        "max_utilization_percentage",
+				Description: "The maximum hourly utilization percentage during the day.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.MaxUtilizationPercentage"),
+			},
+			{
+				Name:        "id",
+				Description: "The resource ID of the utilization summary.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromGo(),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSavingsPlanUtilizationDaily(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	billingAccountID := d.EqualsQualString("billing_account_id")
+	if billingAccountID == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_savings_plan_utilization_daily.listSavingsPlanUtilizationDaily", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armcostmanagement.NewBenefitUtilizationSummariesClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_savings_plan_utilization_daily.listSavingsPlanUtilizationDaily", "client_error", err)
+		return nil, err
+	}
+
+	start, end := getUsageDateRange(d, 30)
+	filter := fmt.Sprintf("properties/usageDate ge %s and properties/usageDate le %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	grain := to.Ptr(armcostmanagement.GrainParameterDaily)
+
+	var billingProfileID *string
+	// nextPage returns the next page of summaries, and false once all pages have been read
+	var nextPage func(ctx context.Context) ([]armcostmanagement.BenefitUtilizationSummaryClassification, bool, error)
+	if profile := d.EqualsQualString("billing_profile_id"); profile != "" {
+		billingProfileID = &profile
+		pager := client.NewListByBillingProfileIDPager(billingAccountID, profile, &armcostmanagement.BenefitUtilizationSummariesClientListByBillingProfileIDOptions{Filter: &filter, GrainParameter: grain})
+		nextPage = func(ctx context.Context) ([]armcostmanagement.BenefitUtilizationSummaryClassification, bool, error) {
+			if !pager.More() {
+				return nil, false, nil
+			}
+			page, err := pager.NextPage(ctx)
+			return page.Value, true, err
+		}
+	} else {
+		pager := client.NewListByBillingAccountIDPager(billingAccountID, &armcostmanagement.BenefitUtilizationSummariesClientListByBillingAccountIDOptions{Filter: &filter, GrainParameter: grain})
+		nextPage = func(ctx context.Context) ([]armcostmanagement.BenefitUtilizationSummaryClassification, bool, error) {
+			if !pager.More() {
+				return nil, false, nil
+			}
+			page, err := pager.NextPage(ctx)
+			return page.Value, true, err
+		}
+	}
+
+	for {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		summaries, more, err := nextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_savings_plan_utilization_daily.listSavingsPlanUtilizationDaily", "api_error", err)
+			return nil, err
+		}
+		if !more {
+			return nil, nil
+		}
+
+		for _, summary := range summaries {
+			// Only savings plans are reported, other benefits such as included quantities are skipped
+			savingsPlan, ok := summary.(*armcostmanagement.SavingsPlanUtilizationSummary)
+			if !ok {
+				continue
+			}
+			d.StreamListItem(ctx, &SavingsPlanUtilizationInfo{billingAccountID, billingProfileID, savingsPlan})
+
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+}
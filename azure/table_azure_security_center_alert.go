@@ -0,0 +1,416 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureSecurityCenterAlert(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_security_center_alert",
+		Description: "Azure Security Center Alert",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSecurityCenterAlert,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "alerts/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "ResourceGroupNotFound", "NotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listSecurityCenterAlerts,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "alerts/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "status",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "severity",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "resource_group",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the alert.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AlertDisplayName"),
+			},
+			{
+				Name:        "alert_type",
+				Description: "The unique identifier of the detection logic, shared by all the alerts of the same type.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AlertType"),
+			},
+			{
+				Name:        "system_alert_id",
+				Description: "The unique identifier of the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.SystemAlertID"),
+			},
+			{
+				Name:        "severity",
+				Description: "The risk level of the threat that was detected. Possible values are Informational, Low, Medium and High.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Severity"),
+			},
+			{
+				Name:        "status",
+				Description: "The life cycle status of the alert. Possible values are Active, InProgress, Resolved and Dismissed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Status"),
+			},
+			{
+				Name:        "intent",
+				Description: "The kill chain intent of the alert, e.g. InitialAccess or Exfiltration.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Intent"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the suspicious activity that was detected.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "compromised_entity",
+				Description: "The display name of the resource most related to the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.CompromisedEntity"),
+			},
+			{
+				Name:        "compromised_resource_id",
+				Description: "The ID of the Azure resource the alert was raised on.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ResourceIdentifiers").Transform(extractAlertAzureResourceID),
+			},
+			{
+				Name:        "start_time_utc",
+				Description: "The time of the first event or activity included in the alert.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.StartTimeUTC"),
+			},
+			{
+				Name:        "end_time_utc",
+				Description: "The time of the last event or activity included in the alert.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.EndTimeUTC"),
+			},
+			{
+				Name:        "time_generated_utc",
+				Description: "The time the alert was generated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.TimeGeneratedUTC"),
+			},
+			{
+				Name:        "processing_end_time_utc",
+				Description: "The time the alert was made available for consumption.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.ProcessingEndTimeUTC"),
+			},
+			{
+				Name:        "is_incident",
+				Description: "True if the alert is an incident, a composition of several alerts.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.IsIncident"),
+			},
+			{
+				Name:        "correlation_key",
+				Description: "The key for correlating related alerts.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.CorrelationKey"),
+			},
+			{
+				Name:        "product_name",
+				Description: "The name of the product which published the alert, e.g. Microsoft Defender for Cloud.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ProductName"),
+			},
+			{
+				Name:        "product_component_name",
+				Description: "The name of the Defender plan that raised the alert, e.g. Storage or Servers.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ProductComponentName"),
+			},
+			{
+				Name:        "vendor_name",
+				Description: "The name of the vendor that raised the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.VendorName"),
+			},
+			{
+				Name:        "alert_uri",
+				Description: "The link to the alert in the Azure portal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AlertURI"),
+			},
+			{
+				Name:        "remediation_steps",
+				Description: "The manual action items to take to remediate the alert.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.RemediationSteps"),
+			},
+			{
+				Name:        "entities",
+				Description: "The entities related to the alert, e.g. hosts, accounts, processes or IP addresses.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Entities"),
+			},
+			{
+				Name:        "resource_identifiers",
+				Description: "The identifiers of the resources the alert was raised on.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ResourceIdentifiers"),
+			},
+			{
+				Name:        "techniques",
+				Description: "The MITRE ATT&CK techniques related to the alert.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Techniques"),
+			},
+			{
+				Name:        "sub_techniques",
+				Description: "The MITRE ATT&CK sub-techniques related to the alert.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.SubTechniques"),
+			},
+			{
+				Name:        "extended_properties",
+				Description: "Custom properties of the alert.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ExtendedProperties"),
+			},
+			{
+				Name:        "extended_links",
+				Description: "Links related to the alert.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ExtendedLinks"),
+			},
+			{
+				Name:        "supporting_evidence",
+				Description: "The evidence supporting the alert.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.SupportingEvidence"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Security/locations/alerts).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AlertDisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "region",
+				Description: "The location where Defender for Cloud stores the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractAlertLocation),
+			},
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractOptionalResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSecurityCenterAlerts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_alert.listSecurityCenterAlerts", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewAlertsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_alert.listSecurityCenterAlerts", "client_error", err)
+		return nil, err
+	}
+
+	// The API does not filter alerts, so the status and severity quals are applied here, before the rows are streamed
+	streamAlerts := func(alerts []*armsecurity.Alert) bool {
+		for _, alert := range alerts {
+			var status, severity *string
+			if alert.Properties != nil {
+				status = (*string)(alert.Properties.Status)
+				severity = (*string)(alert.Properties.Severity)
+			}
+			if !matchesSecurityCenterQuals(d, "status", status) || !matchesSecurityCenterQuals(d, "severity", severity) {
+				continue
+			}
+
+			d.StreamListItem(ctx, *alert)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	if resourceGroup := d.EqualsQualString("resource_group"); resourceGroup != "" {
+		pager := client.NewListByResourceGroupPager(resourceGroup, nil)
+		for pager.More() {
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_security_center_alert.listSecurityCenterAlerts", "api_error", err)
+				return nil, err
+			}
+			if !streamAlerts(page.Value) {
+				return nil, nil
+			}
+		}
+		return nil, nil
+	}
+
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_security_center_alert.listSecurityCenterAlerts", "api_error", err)
+			return nil, err
+		}
+		if !streamAlerts(page.Value) {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getSecurityCenterAlert(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+	resourceGroup, location, name := parseSecurityCenterAlertID(id)
+	if location == "" || name == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_alert.getSecurityCenterAlert", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewAlertsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_alert.getSecurityCenterAlert", "client_error", err)
+		return nil, err
+	}
+
+	// Alerts are stored at the subscription level, or at the resource group level when raised on a resource
+	if resourceGroup != "" {
+		op, err := client.GetResourceGroupLevel(ctx, resourceGroup, location, name, nil)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_security_center_alert.getSecurityCenterAlert", "api_error", err)
+			return nil, err
+		}
+		return op.Alert, nil
+	}
+
+	op, err := client.GetSubscriptionLevel(ctx, location, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_alert.getSecurityCenterAlert", "api_error", err)
+		return nil, err
+	}
+	return op.Alert, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func extractAlertAzureResourceID(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	identifiers, ok := d.Value.([]armsecurity.ResourceIdentifierClassification)
+	if !ok {
+		return nil, nil
+	}
+	for _, identifier := range identifiers {
+		if azureIdentifier, ok := identifier.(*armsecurity.AzureResourceIdentifier); ok && azureIdentifier.AzureResourceID != nil {
+			return *azureIdentifier.AzureResourceID, nil
+		}
+	}
+	return nil, nil
+}
+
+func extractAlertLocation(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	_, location, _ := parseSecurityCenterAlertID(types.SafeString(d.Value))
+	if location == "" {
+		return nil, nil
+	}
+	return location, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// parseSecurityCenterAlertID returns the resource group, location and name of an alert from its ID, e.g.
+// /subscriptions/{id}/resourceGroups/{resourceGroup}/providers/Microsoft.Security/locations/{location}/alerts/{name}.
+// The resource group is empty for alerts stored at the subscription level.
+func parseSecurityCenterAlertID(id string) (resourceGroup string, location string, name string) {
+	parts := strings.Split(id, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		switch strings.ToLower(parts[i]) {
+		case "resourcegroups":
+			resourceGroup = parts[i+1]
+		case "locations":
+			location = parts[i+1]
+		case "alerts":
+			name = parts[i+1]
+		}
+	}
+	return resourceGroup, location, name
+}
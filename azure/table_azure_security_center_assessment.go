@@ -0,0 +1,451 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// SecurityCenterAssessmentInfo is an assessment with the metadata of its assessment type, which is not returned when listing assessments
+type SecurityCenterAssessmentInfo struct {
+	armsecurity.AssessmentResponse
+	Metadata   *armsecurity.AssessmentMetadataPropertiesResponse
+	ResourceID *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureSecurityCenterAssessment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_security_center_assessment",
+		Description: "Azure Security Center Assessment",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate:    getSecurityCenterAssessment,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "assessments/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "NotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listSecurityCenterAssessments,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "assessments/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "status",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "severity",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the assessment, which is the name of its assessment type.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName"),
+			},
+			{
+				Name:        "status",
+				Description: "The status of the assessment. Possible values are Healthy, Unhealthy and NotApplicable.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Status.Code"),
+			},
+			{
+				Name:        "status_cause",
+				Description: "The programmatic code of the cause of the status.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Status.Cause"),
+			},
+			{
+				Name:        "status_description",
+				Description: "The human readable description of the status.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Status.Description"),
+			},
+			{
+				Name:        "status_first_evaluation_date",
+				Description: "The time the assessment was first evaluated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.Status.FirstEvaluationDate"),
+			},
+			{
+				Name:        "status_change_date",
+				Description: "The time the status of the assessment last changed.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.Status.StatusChangeDate"),
+			},
+			{
+				Name:        "severity",
+				Description: "The severity of the assessment. Possible values are High, Medium and Low.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.Severity"),
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the assessed resource, if it is an Azure resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceID"),
+			},
+			{
+				Name:        "resource_details",
+				Description: "The details of the assessed resource.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ResourceDetails"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the assessment type.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.Description"),
+			},
+			{
+				Name:        "remediation_description",
+				Description: "The steps to remediate the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.RemediationDescription"),
+			},
+			{
+				Name:        "assessment_type",
+				Description: "The type of the assessment. Possible values are BuiltIn, CustomPolicy, CustomerManaged and VerifiedPartner.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.AssessmentType"),
+			},
+			{
+				Name:        "categories",
+				Description: "The categories of resources the assessment applies to, e.g. Compute or Networking.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Metadata.Categories"),
+			},
+			{
+				Name:        "threats",
+				Description: "The threats the assessment protects against.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Metadata.Threats"),
+			},
+			{
+				Name:        "implementation_effort",
+				Description: "The effort required to remediate the assessment. Possible values are Low, Moderate and High.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.ImplementationEffort"),
+			},
+			{
+				Name:        "user_impact",
+				Description: "The impact of remediating the assessment on users. Possible values are Low, Moderate and High.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.UserImpact"),
+			},
+			{
+				Name:        "policy_definition_id",
+				Description: "The ID of the policy definition evaluated by the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.PolicyDefinitionID"),
+			},
+			{
+				Name:        "preview",
+				Description: "True if the assessment is in preview.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Metadata.Preview"),
+			},
+			{
+				Name:        "azure_portal_uri",
+				Description: "The link to the assessment in the Azure portal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Links.AzurePortalURI"),
+			},
+			{
+				Name:        "additional_data",
+				Description: "Additional data on the assessment.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.AdditionalData"),
+			},
+			{
+				Name:        "partner_name",
+				Description: "The name of the partner that created the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.PartnersData.PartnerName"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Security/assessments).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+
+			// Azure standard columns
+			{
+				Name:        "resource_group",
+				Description: ColumnDescriptionResourceGroup,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceID").Transform(extractOptionalResourceGroupFromID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSecurityCenterAssessments(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_assessment.listSecurityCenterAssessments", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewAssessmentsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_assessment.listSecurityCenterAssessments", "client_error", err)
+		return nil, err
+	}
+
+	metadata, err := getSecurityCenterAssessmentMetadata(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	pager := client.NewListPager("subscriptions/"+session.SubscriptionID, nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_security_center_assessment.listSecurityCenterAssessments", "api_error", err)
+			return nil, err
+		}
+
+		for _, assessment := range page.Value {
+			item := securityCenterAssessmentInfo(assessment, metadata.(map[string]*armsecurity.AssessmentMetadataPropertiesResponse))
+
+			// The API does not filter assessments, so the status and severity quals are applied here,
+			// before the rows are streamed
+			var status, severity *string
+			if item.Properties != nil && item.Properties.Status != nil {
+				status = (*string)(item.Properties.Status.Code)
+			}
+			if item.Metadata != nil {
+				severity = (*string)(item.Metadata.Severity)
+			}
+			if !matchesSecurityCenterQuals(d, "status", status) || !matchesSecurityCenterQuals(d, "severity", severity) {
+				continue
+			}
+
+			d.StreamListItem(ctx, item)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getSecurityCenterAssessment(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	id := d.EqualsQualString("id")
+	index := strings.Index(strings.ToLower(id), "/providers/microsoft.security/assessments/")
+	if index < 0 {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_assessment.getSecurityCenterAssessment", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewAssessmentsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_assessment.getSecurityCenterAssessment", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, strings.TrimPrefix(id[:index], "/"), getLastPathElement(id), &armsecurity.AssessmentsClientGetOptions{Expand: to.Ptr(armsecurity.ExpandEnumMetadata)})
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_assessment.getSecurityCenterAssessment", "api_error", err)
+		return nil, err
+	}
+
+	metadata, err := getSecurityCenterAssessmentMetadata(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	return securityCenterAssessmentInfo(&op.AssessmentResponse, metadata.(map[string]*armsecurity.AssessmentMetadataPropertiesResponse)), nil
+}
+
+// if the caching is required other than per connection, build a cache key for the call and use it in Memoize.
+var getSecurityCenterAssessmentMetadataMemoized = plugin.HydrateFunc(getSecurityCenterAssessmentMetadataUncached).Memoize(memoize.WithCacheKeyFunction(getSecurityCenterAssessmentMetadataCacheKey))
+
+// declare a wrapper hydrate function to call the memoized function
+// - this is required when a memoized function is used for a column definition
+func getSecurityCenterAssessmentMetadata(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getSecurityCenterAssessmentMetadataMemoized(ctx, d, h)
+}
+
+// Build a cache key for the call to getSecurityCenterAssessmentMetadata.
+func getSecurityCenterAssessmentMetadataCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := "getSecurityCenterAssessmentMetadata"
+	return key, nil
+}
+
+// getSecurityCenterAssessmentMetadataUncached lists the built-in and the custom assessment types of the subscription,
+// returning their metadata keyed by lower case assessment type name
+func getSecurityCenterAssessmentMetadataUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getSecurityCenterAssessmentMetadataUncached", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewAssessmentsMetadataClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("getSecurityCenterAssessmentMetadataUncached", "client_error", err)
+		return nil, err
+	}
+
+	metadata := make(map[string]*armsecurity.AssessmentMetadataPropertiesResponse)
+
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("getSecurityCenterAssessmentMetadataUncached", "api_error", err)
+			return nil, err
+		}
+		for _, item := range page.Value {
+			if item.Name != nil {
+				metadata[strings.ToLower(*item.Name)] = item.Properties
+			}
+		}
+	}
+
+	subscriptionPager := client.NewListBySubscriptionPager(nil)
+	for subscriptionPager.More() {
+		page, err := subscriptionPager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("getSecurityCenterAssessmentMetadataUncached", "api_error", err)
+			return nil, err
+		}
+		for _, item := range page.Value {
+			if item.Name != nil {
+				metadata[strings.ToLower(*item.Name)] = item.Properties
+			}
+		}
+	}
+
+	return metadata, nil
+}
+
+//// UTILITY FUNCTIONS
+
+func securityCenterAssessmentInfo(assessment *armsecurity.AssessmentResponse, metadata map[string]*armsecurity.AssessmentMetadataPropertiesResponse) SecurityCenterAssessmentInfo {
+	item := SecurityCenterAssessmentInfo{AssessmentResponse: *assessment}
+	if assessment.Name != nil {
+		item.Metadata = metadata[strings.ToLower(*assessment.Name)]
+	}
+
+	if assessment.Properties == nil {
+		return item
+	}
+	if details, ok := assessment.Properties.ResourceDetails.(*armsecurity.AzureResourceDetails); ok {
+		item.ResourceID = details.ID
+	}
+
+	// Assessments of custom types may not have metadata of their own, but return it inline
+	if item.Metadata == nil && assessment.Properties.Metadata != nil {
+		m := assessment.Properties.Metadata
+		item.Metadata = &armsecurity.AssessmentMetadataPropertiesResponse{
+			AssessmentType:         m.AssessmentType,
+			DisplayName:            m.DisplayName,
+			Severity:               m.Severity,
+			Categories:             m.Categories,
+			Description:            m.Description,
+			ImplementationEffort:   m.ImplementationEffort,
+			PartnerData:            m.PartnerData,
+			Preview:                m.Preview,
+			RemediationDescription: m.RemediationDescription,
+			Threats:                m.Threats,
+			UserImpact:             m.UserImpact,
+			PolicyDefinitionID:     m.PolicyDefinitionID,
+		}
+	}
+
+	return item
+}
+
+// matchesSecurityCenterQuals returns true if the value matches all the '=' quals on the column.
+// A value matches an IN qual if it matches any of its values.
+func matchesSecurityCenterQuals(d *plugin.QueryData, column string, value *string) bool {
+	if d.Quals[column] == nil {
+		return true
+	}
+	for _, q := range d.Quals[column].Quals {
+		if q.Operator != "=" || q.Value == nil {
+			continue
+		}
+		if value == nil {
+			return false
+		}
+		qualValues := []string{q.Value.GetStringValue()}
+		if listValue := q.Value.GetListValue(); listValue != nil {
+			qualValues = nil
+			for _, v := range listValue.Values {
+				qualValues = append(qualValues, v.GetStringValue())
+			}
+		}
+		matched := false
+		for _, qualValue := range qualValues {
+			if qualValue == *value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,255 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// RegulatoryComplianceAssessmentInfo is a regulatory compliance assessment with the names of its standard and control
+type RegulatoryComplianceAssessmentInfo struct {
+	armsecurity.RegulatoryComplianceAssessment
+	StandardName *string
+	ControlName  *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureSecurityCenterRegulatoryComplianceAssessment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_security_center_regulatory_compliance_assessment",
+		Description: "Azure Security Center Regulatory Compliance Assessment",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"standard_name", "control_name", "name"}),
+			Hydrate:    getSecurityCenterRegulatoryComplianceAssessment,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "regulatoryComplianceStandards/regulatoryComplianceControls/regulatoryComplianceAssessments/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "NotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			ParentHydrate: listSecurityCenterRegulatoryComplianceStandards,
+			Hydrate:       listSecurityCenterRegulatoryComplianceAssessments,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "regulatoryComplianceStandards/regulatoryComplianceControls/regulatoryComplianceAssessments/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "standard_name",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "control_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the regulatory compliance assessment, which is the name of its assessment type.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the regulatory compliance assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "standard_name",
+				Description: "The name of the regulatory compliance standard of the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("StandardName"),
+			},
+			{
+				Name:        "control_name",
+				Description: "The name of the regulatory compliance control of the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ControlName"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "state",
+				Description: "The state of the assessment. Possible values are Passed, Failed, Skipped and Unsupported.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.State"),
+			},
+			{
+				Name:        "assessment_type",
+				Description: "The type of the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AssessmentType"),
+			},
+			{
+				Name:        "assessment_details_link",
+				Description: "The link to the details of the assessment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.AssessmentDetailsLink"),
+			},
+			{
+				Name:        "passed_resources",
+				Description: "The number of resources that passed the assessment.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.PassedResources"),
+			},
+			{
+				Name:        "failed_resources",
+				Description: "The number of resources that failed the assessment.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.FailedResources"),
+			},
+			{
+				Name:        "skipped_resources",
+				Description: "The number of resources that were skipped by the assessment.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.SkippedResources"),
+			},
+			{
+				Name:        "unsupported_resources",
+				Description: "The number of resources the assessment does not support.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.UnsupportedResources"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Security/regulatoryComplianceStandards/regulatoryComplianceControls/regulatoryComplianceAssessments).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSecurityCenterRegulatoryComplianceAssessments(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	standard := h.Item.(armsecurity.RegulatoryComplianceStandard)
+	if standard.Name == nil {
+		return nil, nil
+	}
+
+	// Skip the standards other than the one requested
+	if d.EqualsQualString("standard_name") != "" && d.EqualsQualString("standard_name") != *standard.Name {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_assessment.listSecurityCenterRegulatoryComplianceAssessments", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewRegulatoryComplianceAssessmentsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_assessment.listSecurityCenterRegulatoryComplianceAssessments", "client_error", err)
+		return nil, err
+	}
+
+	// The assessments are listed per control, so list the controls of the standard unless one is requested
+	controlNames := []string{d.EqualsQualString("control_name")}
+	if controlNames[0] == "" {
+		controlsClient, err := armsecurity.NewRegulatoryComplianceControlsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_assessment.listSecurityCenterRegulatoryComplianceAssessments", "client_error", err)
+			return nil, err
+		}
+
+		controlNames = nil
+		controlsPager := controlsClient.NewListPager(*standard.Name, nil)
+		for controlsPager.More() {
+			page, err := controlsPager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_assessment.listSecurityCenterRegulatoryComplianceAssessments", "api_error", err)
+				return nil, err
+			}
+			for _, control := range page.Value {
+				if control.Name != nil {
+					controlNames = append(controlNames, *control.Name)
+				}
+			}
+		}
+	}
+
+	for _, controlName := range controlNames {
+		controlName := controlName
+		pager := client.NewListPager(*standard.Name, controlName, nil)
+		for pager.More() {
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_assessment.listSecurityCenterRegulatoryComplianceAssessments", "api_error", err)
+				return nil, err
+			}
+
+			for _, assessment := range page.Value {
+				d.StreamListItem(ctx, RegulatoryComplianceAssessmentInfo{RegulatoryComplianceAssessment: *assessment, StandardName: standard.Name, ControlName: &controlName})
+				// Check if context has been cancelled or if the limit has been hit (if specified)
+				// if there is a limit, it will return the number of rows required to reach this limit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getSecurityCenterRegulatoryComplianceAssessment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	standardName := d.EqualsQualString("standard_name")
+	controlName := d.EqualsQualString("control_name")
+	name := d.EqualsQualString("name")
+	if standardName == "" || controlName == "" || name == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_assessment.getSecurityCenterRegulatoryComplianceAssessment", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewRegulatoryComplianceAssessmentsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_assessment.getSecurityCenterRegulatoryComplianceAssessment", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, standardName, controlName, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_assessment.getSecurityCenterRegulatoryComplianceAssessment", "api_error", err)
+		return nil, err
+	}
+
+	return RegulatoryComplianceAssessmentInfo{RegulatoryComplianceAssessment: op.RegulatoryComplianceAssessment, StandardName: &standardName, ControlName: &controlName}, nil
+}
@@ -0,0 +1,197 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// RegulatoryComplianceControlInfo is a regulatory compliance control with the name of its standard
+type RegulatoryComplianceControlInfo struct {
+	armsecurity.RegulatoryComplianceControl
+	StandardName *string
+}
+
+//// TABLE DEFINITION
+
+func tableAzureSecurityCenterRegulatoryComplianceControl(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_security_center_regulatory_compliance_control",
+		Description: "Azure Security Center Regulatory Compliance Control",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.AllColumns([]string{"standard_name", "name"}),
+			Hydrate:    getSecurityCenterRegulatoryComplianceControl,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "regulatoryComplianceStandards/regulatoryComplianceControls/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "NotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			ParentHydrate: listSecurityCenterRegulatoryComplianceStandards,
+			Hydrate:       listSecurityCenterRegulatoryComplianceControls,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "regulatoryComplianceStandards/regulatoryComplianceControls/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "standard_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the regulatory compliance control, e.g. 1.1.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the regulatory compliance control.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "standard_name",
+				Description: "The name of the regulatory compliance standard of the control.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("StandardName"),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the control.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Description"),
+			},
+			{
+				Name:        "state",
+				Description: "The state of the control. Possible values are Passed, Failed, Skipped and Unsupported.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.State"),
+			},
+			{
+				Name:        "passed_assessments",
+				Description: "The number of assessments of the control that passed.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.PassedAssessments"),
+			},
+			{
+				Name:        "failed_assessments",
+				Description: "The number of assessments of the control that failed.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.FailedAssessments"),
+			},
+			{
+				Name:        "skipped_assessments",
+				Description: "The number of assessments of the control that were skipped.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.SkippedAssessments"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Security/regulatoryComplianceStandards/regulatoryComplianceControls).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSecurityCenterRegulatoryComplianceControls(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	standard := h.Item.(armsecurity.RegulatoryComplianceStandard)
+	if standard.Name == nil {
+		return nil, nil
+	}
+
+	// Skip the standards other than the one requested
+	if d.EqualsQualString("standard_name") != "" && d.EqualsQualString("standard_name") != *standard.Name {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_control.listSecurityCenterRegulatoryComplianceControls", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewRegulatoryComplianceControlsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_control.listSecurityCenterRegulatoryComplianceControls", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListPager(*standard.Name, nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_control.listSecurityCenterRegulatoryComplianceControls", "api_error", err)
+			return nil, err
+		}
+
+		for _, control := range page.Value {
+			d.StreamListItem(ctx, RegulatoryComplianceControlInfo{RegulatoryComplianceControl: *control, StandardName: standard.Name})
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getSecurityCenterRegulatoryComplianceControl(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	standardName := d.EqualsQualString("standard_name")
+	name := d.EqualsQualString("name")
+	if standardName == "" || name == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_control.getSecurityCenterRegulatoryComplianceControl", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewRegulatoryComplianceControlsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_control.getSecurityCenterRegulatoryComplianceControl", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, standardName, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_control.getSecurityCenterRegulatoryComplianceControl", "api_error", err)
+		return nil, err
+	}
+
+	return RegulatoryComplianceControlInfo{RegulatoryComplianceControl: op.RegulatoryComplianceControl, StandardName: &standardName}, nil
+}
@@ -0,0 +1,167 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureSecurityCenterRegulatoryComplianceStandard(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_security_center_regulatory_compliance_standard",
+		Description: "Azure Security Center Regulatory Compliance Standard",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("name"),
+			Hydrate:    getSecurityCenterRegulatoryComplianceStandard,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "regulatoryComplianceStandards/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "NotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listSecurityCenterRegulatoryComplianceStandards,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "regulatoryComplianceStandards/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the regulatory compliance standard, e.g. Azure-CIS-1.1.0 or PCI-DSS-4.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the regulatory compliance standard.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "state",
+				Description: "The state of the standard. Possible values are Passed, Failed, Skipped and Unsupported.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.State"),
+			},
+			{
+				Name:        "passed_controls",
+				Description: "The number of controls of the standard that passed.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.PassedControls"),
+			},
+			{
+				Name:        "failed_controls",
+				Description: "The number of controls of the standard that failed.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.FailedControls"),
+			},
+			{
+				Name:        "skipped_controls",
+				Description: "The number of controls of the standard that were skipped.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.SkippedControls"),
+			},
+			{
+				Name:        "unsupported_controls",
+				Description: "The number of controls of the standard that are not supported by Defender for Cloud.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.UnsupportedControls"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Security/regulatoryComplianceStandards).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSecurityCenterRegulatoryComplianceStandards(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_standard.listSecurityCenterRegulatoryComplianceStandards", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewRegulatoryComplianceStandardsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_standard.listSecurityCenterRegulatoryComplianceStandards", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_standard.listSecurityCenterRegulatoryComplianceStandards", "api_error", err)
+			return nil, err
+		}
+
+		for _, standard := range page.Value {
+			d.StreamListItem(ctx, *standard)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getSecurityCenterRegulatoryComplianceStandard(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	if name == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_standard.getSecurityCenterRegulatoryComplianceStandard", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewRegulatoryComplianceStandardsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_standard.getSecurityCenterRegulatoryComplianceStandard", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_regulatory_compliance_standard.getSecurityCenterRegulatoryComplianceStandard", "api_error", err)
+		return nil, err
+	}
+
+	return op.RegulatoryComplianceStandard, nil
+}
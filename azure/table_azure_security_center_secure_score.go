@@ -0,0 +1,167 @@
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureSecurityCenterSecureScore(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_security_center_secure_score",
+		Description: "Azure Security Center Secure Score",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("name"),
+			Hydrate:    getSecurityCenterSecureScore,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "secureScores/read",
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError([]string{"ResourceNotFound", "NotFound"}),
+			},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listSecurityCenterSecureScores,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "secureScores/read",
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the secure score. The overall secure score of the subscription is named ascScore.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the secure score.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the secure score.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName"),
+			},
+			{
+				Name:        "current_score",
+				Description: "The current score.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.Score.Current"),
+			},
+			{
+				Name:        "max_score",
+				Description: "The maximum score.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.Score.Max"),
+			},
+			{
+				Name:        "percentage",
+				Description: "The current score as a ratio of the maximum score, between 0 and 1.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.Score.Percentage"),
+			},
+			{
+				Name:        "weight",
+				Description: "The number of resources the secure score is computed on.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.Weight"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Security/secureScores).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSecurityCenterSecureScores(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_secure_score.listSecurityCenterSecureScores", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewSecureScoresClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_secure_score.listSecurityCenterSecureScores", "client_error", err)
+		return nil, err
+	}
+
+	pager := client.NewListPager(nil)
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_security_center_secure_score.listSecurityCenterSecureScores", "api_error", err)
+			return nil, err
+		}
+
+		for _, score := range page.Value {
+			d.StreamListItem(ctx, score)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getSecurityCenterSecureScore(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.EqualsQualString("name")
+	if name == "" {
+		return nil, nil
+	}
+
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_secure_score.getSecurityCenterSecureScore", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewSecureScoresClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_secure_score.getSecurityCenterSecureScore", "client_error", err)
+		return nil, err
+	}
+
+	op, err := client.Get(ctx, name, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_secure_score.getSecurityCenterSecureScore", "api_error", err)
+		return nil, err
+	}
+
+	return op.SecureScoreItem, nil
+}
@@ -0,0 +1,219 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAzureSecurityCenterSecureScoreControl(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "azure_security_center_secure_score_control",
+		Description: "Azure Security Center Secure Score Control",
+		List: &plugin.ListConfig{
+			Hydrate: listSecurityCenterSecureScoreControls,
+			Tags: map[string]string{
+				"service": "Microsoft.Security",
+				"action":  "secureScoreControls/read",
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "secure_score_name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Columns: azureColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the secure score control.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the secure score control.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "display_name",
+				Description: "The display name of the secure score control.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName"),
+			},
+			{
+				Name:        "secure_score_name",
+				Description: "The name of the secure score the control contributes to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID").Transform(extractSecureScoreName),
+			},
+			{
+				Name:        "description",
+				Description: "The description of the secure score control.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Definition.Properties.Description"),
+			},
+			{
+				Name:        "current_score",
+				Description: "The current score of the control.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.Score.Current"),
+			},
+			{
+				Name:        "max_score",
+				Description: "The maximum score of the control.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.Score.Max"),
+			},
+			{
+				Name:        "percentage",
+				Description: "The current score of the control as a ratio of its maximum score, between 0 and 1.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Properties.Score.Percentage"),
+			},
+			{
+				Name:        "healthy_resource_count",
+				Description: "The number of healthy resources in the control.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.HealthyResourceCount"),
+			},
+			{
+				Name:        "unhealthy_resource_count",
+				Description: "The number of unhealthy resources in the control.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.UnhealthyResourceCount"),
+			},
+			{
+				Name:        "not_applicable_resource_count",
+				Description: "The number of resources the control does not apply to.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.NotApplicableResourceCount"),
+			},
+			{
+				Name:        "weight",
+				Description: "The relative weight of the control in the secure score.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Properties.Weight"),
+			},
+			{
+				Name:        "source_type",
+				Description: "The type of the control. Possible values are BuiltIn and Custom.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Definition.Properties.Source.SourceType"),
+			},
+			{
+				Name:        "assessment_definitions",
+				Description: "The IDs of the assessment types evaluated by the control.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Definition.Properties.AssessmentDefinitions"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the resource (Microsoft.Security/secureScores/secureScoreControls).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: ColumnDescriptionTitle,
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.DisplayName", "Name"),
+			},
+			{
+				Name:        "akas",
+				Description: ColumnDescriptionAkas,
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ID").Transform(idToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSecurityCenterSecureScoreControls(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_secure_score_control.listSecurityCenterSecureScoreControls", "session_error", err)
+		return nil, err
+	}
+
+	client, err := armsecurity.NewSecureScoreControlsClient(session.SubscriptionID, session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_secure_score_control.listSecurityCenterSecureScoreControls", "client_error", err)
+		return nil, err
+	}
+
+	// Expand the definition of the controls, for their description and assessment types
+	expand := to.Ptr(armsecurity.ExpandControlsEnumDefinition)
+
+	if secureScoreName := d.EqualsQualString("secure_score_name"); secureScoreName != "" {
+		pager := client.NewListBySecureScorePager(secureScoreName, &armsecurity.SecureScoreControlsClientListBySecureScoreOptions{Expand: expand})
+		for pager.More() {
+			// Wait for rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("azure_security_center_secure_score_control.listSecurityCenterSecureScoreControls", "api_error", err)
+				return nil, err
+			}
+
+			for _, control := range page.Value {
+				d.StreamListItem(ctx, control)
+				// Check if context has been cancelled or if the limit has been hit (if specified)
+				// if there is a limit, it will return the number of rows required to reach this limit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	pager := client.NewListPager(&armsecurity.SecureScoreControlsClientListOptions{Expand: expand})
+	for pager.More() {
+		// Wait for rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("azure_security_center_secure_score_control.listSecurityCenterSecureScoreControls", "api_error", err)
+			return nil, err
+		}
+
+		for _, control := range page.Value {
+			d.StreamListItem(ctx, control)
+			// Check if context has been cancelled or if the limit has been hit (if specified)
+			// if there is a limit, it will return the number of rows required to reach this limit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// extractSecureScoreName returns the name of the secure score from the ID of a secure score control,
+// e.g. /subscriptions/{id}/providers/Microsoft.Security/secureScores/ascScore/secureScoreControls/{name}
+func extractSecureScoreName(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	parts := strings.Split(types.SafeString(d.Value), "/")
+	for i, part := range parts {
+		if strings.EqualFold(part, "secureScores") && i+1 < len(parts) {
+			return parts[i+1], nil
+		}
+	}
+	return nil, nil
+}
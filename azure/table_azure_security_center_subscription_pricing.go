@@ -2,14 +2,21 @@ package azure
 
 import (
 	"context"
+	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/preview/preview/security/mgmt/security"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
+// SecurityCenterPricingInfo is a Defender plan pricing with the scope it is set at
+type SecurityCenterPricingInfo struct {
+	armsecurity.Pricing
+	Scope *string
+}
+
 //// TABLE DEFINITION
 
 func tableAzureSecurityCenterPricing(_ context.Context) *plugin.Table {
@@ -17,8 +24,17 @@ func tableAzureSecurityCenterPricing(_ context.Context) *plugin.Table {
 		Name:        "azure_security_center_subscription_pricing",
 		Description: "Azure Security Center Subscription Pricing",
 		Get: &plugin.GetConfig{
-			KeyColumns: plugin.SingleColumn("name"),
-			Hydrate:    getSecurityCenterPricing,
+			KeyColumns: plugin.KeyColumnSlice{
+				{
+					Name:    "name",
+					Require: plugin.Required,
+				},
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+			},
+			Hydrate: getSecurityCenterPricing,
 			Tags: map[string]string{
 				"service": "Microsoft.Security",
 				"action":  "pricings/read",
@@ -30,37 +46,102 @@ func tableAzureSecurityCenterPricing(_ context.Context) *plugin.Table {
 				"service": "Microsoft.Security",
 				"action":  "pricings/read",
 			},
+			KeyColumns: plugin.KeyColumnSlice{
+				// The scope may be given with or without the leading "/" (forward slash)
+				{
+					Name:    "scope",
+					Require: plugin.Optional,
+				},
+			},
 		},
 		Columns: azureColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "The pricing id.",
 				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromGo(),
+				Transform:   transform.FromField("ID"),
 			},
 			{
 				Name:        "name",
 				Description: "Name of the pricing.",
 				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "scope",
+				Description: "The scope of the pricing, which is the subscription, or a resource for plans that support per-resource pricing, e.g. VirtualMachines. Defaults to the subscription.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Scope"),
 			},
 			{
 				Name:        "pricing_tier",
 				Type:        proto.ColumnType_STRING,
 				Description: "The pricing tier value. Azure Security Center is provided in two pricing tiers: free and standard, with the standard tier available with a trial period. The standard tier offers advanced security capabilities, while the free tier offers basic security features.",
-				Transform:   transform.FromField("PricingProperties.PricingTier"),
+				Transform:   transform.FromField("Properties.PricingTier"),
+			},
+			{
+				Name:        "sub_plan",
+				Description: "The sub-plan selected for a Standard pricing configuration, e.g. P1 or P2 for servers.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.SubPlan"),
 			},
 			{
 				Name:        "free_trial_remaining_time",
 				Description: "The duration left for the subscriptions free trial period.",
 				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromField("PricingProperties.FreeTrialRemainingTime"),
+				Transform:   transform.FromField("Properties.FreeTrialRemainingTime"),
+			},
+			{
+				Name:        "enablement_time",
+				Description: "The time the Standard pricing tier was last enabled.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Properties.EnablementTime"),
+			},
+			{
+				Name:        "deprecated",
+				Description: "True if the plan is deprecated.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Properties.Deprecated"),
+			},
+			{
+				Name:        "replaced_by",
+				Description: "The plans replacing this plan, if it is deprecated.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.ReplacedBy"),
+			},
+			{
+				Name:        "extensions",
+				Description: "The extensions of the plan, e.g. AgentlessVmScanning, SensitiveDataDiscovery or ContainerRegistriesVulnerabilityAssessments, and whether they are enabled.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Properties.Extensions"),
+			},
+			{
+				Name:        "enforce",
+				Description: "Whether the pricing is enforced on the descendants of the scope, overriding their own configuration. Possible values are True and False.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Enforce"),
+			},
+			{
+				Name:        "inherited",
+				Description: "Whether the pricing is inherited from a parent scope. Possible values are True and False.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.Inherited"),
+			},
+			{
+				Name:        "inherited_from",
+				Description: "The ID of the scope the pricing is inherited from.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.InheritedFrom"),
+			},
+			{
+				Name:        "resources_coverage_status",
+				Description: "Whether all the resources under the scope are covered by the plan. Possible values are FullyCovered, PartiallyCovered and NotCovered.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Properties.ResourcesCoverageStatus"),
 			},
 			{
 				Name:        "type",
 				Type:        proto.ColumnType_STRING,
 				Description: "Type of the pricing.",
-				Transform:   transform.FromGo(),
 			},
 
 			// Steampipe standard columns
@@ -83,25 +164,26 @@ func tableAzureSecurityCenterPricing(_ context.Context) *plugin.Table {
 //// LIST FUNCTION
 
 func listSecurityCenterPricings(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	session, err := GetNewSession(ctx, d, "MANAGEMENT")
+	session, err := GetNewSessionUpdated(ctx, d)
 	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_subscription_pricing.listSecurityCenterPricings", "session_error", err)
 		return nil, err
 	}
 
-	subscriptionID := session.SubscriptionID
-	settingClient := security.NewPricingsClientWithBaseURI(session.ResourceManagerEndpoint, subscriptionID)
-	settingClient.Authorizer = session.Authorizer
-
-	// Apply Retry rule
-	ApplyRetryRules(ctx, &settingClient, d.Connection)
+	client, err := armsecurity.NewPricingsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_subscription_pricing.listSecurityCenterPricings", "client_error", err)
+		return nil, err
+	}
 
-	result, err := settingClient.List(ctx)
+	result, err := client.List(ctx, getSecurityCenterPricingScope(d, session.SubscriptionID), nil)
 	if err != nil {
-		return err, nil
+		plugin.Logger(ctx).Error("azure_security_center_subscription_pricing.listSecurityCenterPricings", "api_error", err)
+		return nil, err
 	}
 
-	for _, pricing := range *result.Value {
-		d.StreamListItem(ctx, pricing)
+	for _, pricing := range result.Value {
+		d.StreamListItem(ctx, securityCenterPricingInfo(d, pricing))
 		// Check if context has been cancelled or if the limit has been hit (if specified)
 		// if there is a limit, it will return the number of rows required to reach this limit
 		if d.RowsRemaining(ctx) == 0 {
@@ -115,10 +197,6 @@ func listSecurityCenterPricings(ctx context.Context, d *plugin.QueryData, _ *plu
 //// HYDRATE FUNCTIONS
 
 func getSecurityCenterPricing(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	session, err := GetNewSession(ctx, d, "MANAGEMENT")
-	if err != nil {
-		return nil, err
-	}
 	name := d.EqualsQuals["name"].GetStringValue()
 
 	// Handle empty input for get call
@@ -126,17 +204,51 @@ func getSecurityCenterPricing(ctx context.Context, d *plugin.QueryData, _ *plugi
 		return nil, nil
 	}
 
-	subscriptionID := session.SubscriptionID
-	settingClient := security.NewPricingsClientWithBaseURI(session.ResourceManagerEndpoint, subscriptionID)
-	settingClient.Authorizer = session.Authorizer
+	session, err := GetNewSessionUpdated(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_subscription_pricing.getSecurityCenterPricing", "session_error", err)
+		return nil, err
+	}
 
-	// Apply Retry rule
-	ApplyRetryRules(ctx, &settingClient, d.Connection)
+	client, err := armsecurity.NewPricingsClient(session.Cred, session.ClientOptions)
+	if err != nil {
+		plugin.Logger(ctx).Error("azure_security_center_subscription_pricing.getSecurityCenterPricing", "client_error", err)
+		return nil, err
+	}
 
-	setting, err := settingClient.Get(ctx, name)
+	op, err := client.Get(ctx, getSecurityCenterPricingScope(d, session.SubscriptionID), name, nil)
 	if err != nil {
-		return err, nil
+		plugin.Logger(ctx).Error("azure_security_center_subscription_pricing.getSecurityCenterPricing", "api_error", err)
+		return nil, err
+	}
+
+	return securityCenterPricingInfo(d, &op.Pricing), nil
+}
+
+//// UTILITY FUNCTIONS
+
+// getSecurityCenterPricingScope returns the scope of the pricings to query, which is the subscription unless a resource is given
+func getSecurityCenterPricingScope(d *plugin.QueryData, subscriptionID string) string {
+	if scope := d.EqualsQualString("scope"); scope != "" {
+		return strings.Trim(scope, "/")
 	}
+	return "subscriptions/" + subscriptionID
+}
 
-	return setting, nil
+func securityCenterPricingInfo(d *plugin.QueryData, pricing *armsecurity.Pricing) SecurityCenterPricingInfo {
+	item := SecurityCenterPricingInfo{Pricing: *pricing}
+
+	// Report the scope as queried, so that the pricing is not dropped for differing in the leading slash
+	if scope := d.EqualsQualString("scope"); scope != "" {
+		item.Scope = &scope
+		return item
+	}
+
+	if pricing.ID != nil {
+		if index := strings.Index(strings.ToLower(*pricing.ID), "/providers/microsoft.security/pricings/"); index >= 0 {
+			scope := (*pricing.ID)[:index]
+			item.Scope = &scope
+		}
+	}
+	return item
 }
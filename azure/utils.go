@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/consumption/mgmt/consumption"
 	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
@@ -39,6 +40,18 @@ func extractResourceGroupFromID(ctx context.Context, d *transform.TransformData)
 	return resourceGroup, nil
 }
 
+// extractOptionalResourceGroupFromID returns the resource group of an ID, or nil if the resource is not in a
+// resource group, e.g. a subscription or management group
+func extractOptionalResourceGroupFromID(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	splitID := strings.Split(types.SafeString(d.Value), "/")
+	for i := 0; i < len(splitID)-1; i++ {
+		if strings.EqualFold(splitID[i], "resourceGroups") {
+			return strings.ToLower(splitID[i+1]), nil
+		}
+	}
+	return nil, nil
+}
+
 func lastPathElement(_ context.Context, d *transform.TransformData) (interface{}, error) {
 	return getLastPathElement(types.SafeString(d.Value)), nil
 }
@@ -68,6 +81,63 @@ func convertDateToTime(ctx context.Context, d *transform.TransformData) (interfa
 	return nil, nil
 }
 
+// convertDateOnlyToTime converts a date without a time component, as returned by the reservations API, into a time
+func convertDateOnlyToTime(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	dateValue, ok := d.Value.(*date.Date)
+	if !ok || dateValue == nil {
+		return nil, nil
+	}
+	return dateValue.ToTime(), nil
+}
+
+// decimalToFloat64 converts a decimal value, as returned by the consumption APIs, into a float
+func decimalToFloat64(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	value, ok := d.Value.(interface{ Float64() (float64, bool) })
+	if !ok {
+		return nil, nil
+	}
+	if f := decimalPtrToFloat64(value); f != nil {
+		return *f, nil
+	}
+	return nil, nil
+}
+
+// decimalPtrToFloat64 converts an optional decimal value into an optional float
+func decimalPtrToFloat64(value interface{ Float64() (float64, bool) }) *float64 {
+	if v := reflect.ValueOf(value); !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil
+	}
+	f, _ := value.Float64()
+	return &f
+}
+
+// getQualValueCasing returns the '=' qual value on the column that matches the value case-insensitively, or the value itself.
+// Some APIs return IDs and names in lower case, so reporting the qual value instead keeps rows that match a filter,
+// e.g. a join on azure_resource.id, from being dropped when Postgres rechecks the quals.
+func getQualValueCasing(d *plugin.QueryData, column string, value string) string {
+	if d.Quals[column] == nil {
+		return value
+	}
+	for _, q := range d.Quals[column].Quals {
+		if q.Operator != "=" || q.Value == nil {
+			continue
+		}
+		qualValues := []string{q.Value.GetStringValue()}
+		if listValue := q.Value.GetListValue(); listValue != nil {
+			qualValues = nil
+			for _, v := range listValue.Values {
+				qualValues = append(qualValues, v.GetStringValue())
+			}
+		}
+		for _, qualValue := range qualValues {
+			if strings.EqualFold(qualValue, value) {
+				return qualValue
+			}
+		}
+	}
+	return value
+}
+
 func structToMap(val reflect.Value) map[string]interface{} {
 	result := make(map[string]interface{})
 